@@ -0,0 +1,89 @@
+package eris
+
+// Definition declares a kind of error once, with a stable ID, a code and a message format. Errors created from
+// a definition carry its ID, so they can be matched with Is regardless of their message arguments and KVs:
+//
+//	var ErrTableNotFound = eris.Define("TABLE_NOT_FOUND", eris.CodeNotFound, "table %q not found")
+//
+//	err := ErrTableNotFound.New("users")
+//	eris.Is(err, ErrTableNotFound) // true
+type Definition struct {
+	id     string
+	code   Code
+	format string
+}
+
+// Define returns a new definition. The ID should be unique and must not change, since it identifies the error
+// kind across releases, e.g. in logs and client code.
+func Define(id string, code Code, format string) *Definition {
+	return &Definition{id: id, code: code, format: format}
+}
+
+// ID returns the stable ID of the definition.
+func (d *Definition) ID() string {
+	return d.id
+}
+
+// Code returns the code of errors created from the definition.
+func (d *Definition) Code() Code {
+	return d.code
+}
+
+// Format returns the message format of the definition.
+func (d *Definition) Format() string {
+	return d.format
+}
+
+// Error returns the ID of the definition, so a definition can be used as target of Is.
+func (d *Definition) Error() string {
+	return d.id
+}
+
+// New creates a new root error with the code of the definition and its message format applied to args.
+func (d *Definition) New(args ...any) statusError {
+	stack := callers(3)
+	root := &rootError{
+		global: stack.isGlobal(),
+		msg:    formatMsg(d.format, args, RedactMask),
+		format: d.format,
+		args:   args,
+		vars:   newMsgVariants(d.format, args),
+		stack:  stack,
+		code:   d.code,
+		coded:  true,
+		id:     d.id,
+	}
+	notifyCreate(OpNew, root)
+	return root
+}
+
+// Wrap wraps an error with the code of the definition and its message format applied to args.
+func (d *Definition) Wrap(err error, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	newErr := wrap(err, formatMsg(d.format, args, RedactMask), d.format, args, d.code)
+	switch e := newErr.(type) {
+	case *rootError:
+		e.coded, e.id = true, d.id
+	case *wrapError:
+		e.coded, e.id = true, d.id
+	}
+	return newErr
+}
+
+// GetID returns the ID of the outermost error in the chain that was created from a definition, or an empty
+// string if there is none.
+func GetID(err error) string {
+	type IDer interface {
+		ID() string
+	}
+	var id string
+	walk(err, func(err error) bool {
+		if idErr, ok := err.(IDer); ok {
+			id = idErr.ID()
+		}
+		return id == ""
+	})
+	return id
+}
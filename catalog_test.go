@@ -0,0 +1,108 @@
+package eris_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+var (
+	errTableNotFound  = eris.Define("TABLE_NOT_FOUND", eris.CodeNotFound, "table %q not found")
+	errSchemaNotFound = eris.Define("SCHEMA_NOT_FOUND", eris.CodeNotFound, "schema %q not found")
+)
+
+func TestDefinition(t *testing.T) {
+	tests := map[string]struct {
+		err   error
+		def   *eris.Definition
+		match bool
+		msg   string
+		id    string
+	}{
+		"new": {
+			err:   errTableNotFound.New("users"),
+			def:   errTableNotFound,
+			match: true,
+			msg:   `table "users" not found`,
+			id:    "TABLE_NOT_FOUND",
+		},
+		"different args and KVs": {
+			err:   errTableNotFound.New("orders").WithProperty("schema", "public"),
+			def:   errTableNotFound,
+			match: true,
+			msg:   `table "orders" not found`,
+			id:    "TABLE_NOT_FOUND",
+		},
+		"wrapped": {
+			err:   eris.Wrap(errTableNotFound.New("users"), "query failed"),
+			def:   errTableNotFound,
+			match: true,
+			msg:   `table "users" not found`,
+			id:    "TABLE_NOT_FOUND",
+		},
+		"definition wraps external": {
+			err:   errSchemaNotFound.Wrap(errors.New("catalog lookup failed"), "public"),
+			def:   errSchemaNotFound,
+			match: true,
+			msg:   `schema "public" not found`,
+			id:    "SCHEMA_NOT_FOUND",
+		},
+		"definition wraps definition": {
+			err:   errSchemaNotFound.Wrap(errTableNotFound.New("users"), "public"),
+			def:   errTableNotFound,
+			match: true,
+			msg:   `table "users" not found`,
+			id:    "SCHEMA_NOT_FOUND",
+		},
+		"other definition": {
+			err:   errTableNotFound.New("users"),
+			def:   errSchemaNotFound,
+			match: false,
+			msg:   `table "users" not found`,
+			id:    "TABLE_NOT_FOUND",
+		},
+		"same message without definition": {
+			err:   eris.Errorf("table %q not found", "users").WithCode(eris.CodeNotFound),
+			def:   errTableNotFound,
+			match: false,
+			msg:   `table "users" not found`,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if match := eris.Is(tc.err, tc.def); match != tc.match {
+				t.Errorf("%v: expected Is { %v } got { %v }", desc, tc.match, match)
+			}
+			if match := errors.Is(tc.err, tc.def); match != tc.match {
+				t.Errorf("%v: expected errors.Is { %v } got { %v }", desc, tc.match, match)
+			}
+			if msg := eris.Unpack(tc.err).ErrRoot.Msg; msg != tc.msg {
+				t.Errorf("%v: expected root message { %v } got { %v }", desc, tc.msg, msg)
+			}
+			if id := eris.GetID(tc.err); id != tc.id {
+				t.Errorf("%v: expected ID { %v } got { %v }", desc, tc.id, id)
+			}
+		})
+	}
+}
+
+func TestDefinitionCode(t *testing.T) {
+	err := errTableNotFound.New("users")
+	if !eris.HasExplicitCode(err) || eris.GetCode(err) != eris.CodeNotFound {
+		t.Errorf("expected explicit code { %v } got { %v }", eris.CodeNotFound, eris.GetCode(err))
+	}
+	if frame := eris.Unpack(err).ErrRoot.Stack[0]; frame.Name != "eris_test.TestDefinitionCode" {
+		t.Errorf("expected stack to start at { %v } got { %v }", "eris_test.TestDefinitionCode", frame.Name)
+	}
+	if errTableNotFound.Wrap(nil, "users") != nil {
+		t.Errorf("expected nil error")
+	}
+	wrapped := errSchemaNotFound.Wrap(err, "public")
+	if eris.GetCode(wrapped) != eris.CodeNotFound || !eris.HasExplicitCode(wrapped) {
+		t.Errorf("expected explicit code { %v } got { %v }", eris.CodeNotFound, eris.GetCode(wrapped))
+	}
+	if id := eris.ToJSON(wrapped, false)["wrap"].([]map[string]any)[0]["id"]; id != "SCHEMA_NOT_FOUND" {
+		t.Errorf("expected ID { %v } in JSON got { %v }", "SCHEMA_NOT_FOUND", id)
+	}
+}
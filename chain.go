@@ -0,0 +1,248 @@
+package eris
+
+// Precedence defines which value wins if the same property is set on multiple errors of a chain.
+type Precedence uint8
+
+const (
+	// OutermostWins keeps the value of the outermost error that sets a property.
+	OutermostWins Precedence = iota
+	// InnermostWins keeps the value of the innermost error (closest to the root cause) that sets a property.
+	InnermostWins
+)
+
+// walk calls fn for err and every error in its chain in depth-first order, following both `Unwrap() error`
+// and `Unwrap() []error`. The walk stops as soon as fn returns false, in which case walk returns false as well.
+func walk(err error, fn func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(x.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if !walk(e, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// chainRoot returns the last error of a chain that is only linked via `Unwrap() error`, if it is a root error.
+func chainRoot(err error) (*rootError, bool) {
+	for {
+		uerr := Unwrap(err)
+		if uerr == nil {
+			root, ok := err.(*rootError)
+			return root, ok
+		}
+		err = uerr
+	}
+}
+
+// AllKVs returns the key-value pairs of all errors in the chain, including joined errors and external errors
+// implementing `KVs() map[string]any`. If a key is set multiple times, the outermost value wins.
+func AllKVs(err error) map[string]any {
+	return CollectKVs(err, OutermostWins)
+}
+
+// CollectKVs returns the key-value pairs of all errors in the chain, including joined errors and external errors
+// implementing `KVs() map[string]any`. The precedence decides which value is kept if a key is set multiple times.
+func CollectKVs(err error, precedence Precedence) map[string]any {
+	kvs := make(map[string]any)
+	walk(err, func(err error) bool {
+		for k, v := range GetKVs(err) {
+			if _, ok := kvs[k]; !ok || precedence == InnermostWins {
+				kvs[k] = v
+			}
+		}
+		return true
+	})
+	return kvs
+}
+
+// FindProperty returns the property from the outermost error in the chain that sets it.
+// If the property doesn't exist or type doesn't match, returns T{}, false.
+func FindProperty[T any](err error, key string) (T, bool) {
+	val, ok := AllKVs(err)[key]
+	if !ok {
+		var empty T
+		return empty, false
+	}
+	typed, ok := unwrapValue(val).(T)
+	if !ok {
+		var empty T
+		return empty, false
+	}
+	return typed, true
+}
+
+// FindPropertyP returns the property pointer from the outermost error in the chain that sets it.
+// If the property doesn't exist or type doesn't match, returns nil.
+func FindPropertyP[T any](err error, key string) *T {
+	typed, ok := FindProperty[T](err, key)
+	if !ok {
+		return nil
+	}
+	return &typed
+}
+
+// explicitCode returns the outermost explicitly set code in the chain, or false if there is none.
+func explicitCode(err error) (Code, bool) {
+	code := DEFAULT_UNKNOWN_CODE
+	found := !walk(err, func(err error) bool {
+		if HasExplicitCode(err) {
+			code = GetCode(err)
+			return false
+		}
+		return true
+	})
+	return code, found
+}
+
+// CodePolicy defines how the effective code of an error chain is resolved.
+type CodePolicy uint8
+
+const (
+	// OutermostExplicit resolves to the outermost code that was set explicitly. Codes assigned by default, e.g.
+	// 'internal' by Wrap, are skipped.
+	OutermostExplicit CodePolicy = iota
+	// Innermost resolves to the innermost explicit code, i.e. the code closest to the root cause.
+	Innermost
+	// MostSevere resolves to the most severe explicit code in the chain.
+	MostSevere
+)
+
+// EffectiveCode returns the code of an error chain according to the given policy. The chain includes joined
+// errors and external errors implementing `Code() Code`.
+//
+// If no code in the chain was set explicitly, the code of the outermost error is returned for OutermostExplicit
+// and MostSevere, and the code of the innermost error for Innermost. Errors without any code resolve to unknown.
+func EffectiveCode(err error, policy CodePolicy) Code {
+	type Coder interface {
+		Code() Code
+	}
+	var codes, explicit []Code
+	walk(err, func(err error) bool {
+		if codeErr, ok := err.(Coder); ok {
+			codes = append(codes, codeErr.Code())
+			if HasExplicitCode(err) {
+				explicit = append(explicit, codeErr.Code())
+			}
+		}
+		return true
+	})
+	if len(codes) == 0 {
+		return DEFAULT_UNKNOWN_CODE
+	}
+
+	switch policy {
+	case Innermost:
+		if len(explicit) > 0 {
+			return explicit[len(explicit)-1]
+		}
+		return codes[len(codes)-1]
+	case MostSevere:
+		if len(explicit) == 0 {
+			return codes[0]
+		}
+		code := explicit[0]
+		for _, c := range explicit[1:] {
+			if c.MoreSevere(code) {
+				code = c
+			}
+		}
+		return code
+	default:
+		if len(explicit) > 0 {
+			return explicit[0]
+		}
+		return codes[0]
+	}
+}
+
+// ErrView is a read-only view of a single error in a chain, as passed to the predicate of Find.
+type ErrView struct {
+	Err      error          // The error itself.
+	Msg      string         // Message of this error only, without the messages of the errors it wraps.
+	Code     Code           // Code of this error, unknown for errors without code.
+	Explicit bool           // Flag indicating whether the code was set explicitly.
+	KVs      map[string]any // Key-value pairs of this error.
+	Frame    StackFrame     // Frame where the error was created, empty for external errors.
+}
+
+// newErrView builds the view of a single error.
+func newErrView(err error) ErrView {
+	view := ErrView{
+		Err:      err,
+		Code:     GetCode(err),
+		Explicit: HasExplicitCode(err),
+		KVs:      GetKVs(err),
+	}
+	switch e := err.(type) {
+	case *rootError:
+		view.Msg = e.msg
+		if frames := e.stack.get(); len(frames) > 0 {
+			view.Frame = frames[0]
+		}
+	case *wrapError:
+		view.Msg = e.msg
+		view.Frame = e.frame.get()
+	default:
+		view.Msg = err.Error()
+	}
+	return view
+}
+
+// Find returns the first error in the chain for which the predicate returns true. The chain is traversed in
+// depth-first order and includes errors joined via `Unwrap() []error`.
+func Find(err error, predicate func(ErrView) bool) (ErrView, bool) {
+	var found ErrView
+	ok := !walk(err, func(err error) bool {
+		view := newErrView(err)
+		if predicate(view) {
+			found = view
+			return false
+		}
+		return true
+	})
+	return found, ok
+}
+
+// IsCode reports whether the error chain has one of the given codes.
+//
+// Only explicitly set codes are considered, including those of joined errors, so the default codes assigned by
+// Wrap don't mask the real code. In addition, the effective code of the chain is considered, so IsCode(err, c) is
+// true whenever EffectiveCode(err, OutermostExplicit) == c, e.g. for the default code of a chain without explicit
+// codes.
+func IsCode(err error, codes ...Code) bool {
+	if err == nil {
+		return false
+	}
+	matches := func(code Code) bool {
+		for _, c := range codes {
+			if code == c {
+				return true
+			}
+		}
+		return false
+	}
+	if matches(EffectiveCode(err, OutermostExplicit)) {
+		return true
+	}
+	return !walk(err, func(err error) bool {
+		return !HasExplicitCode(err) || !matches(GetCode(err))
+	})
+}
+
+// HasProperty reports whether any error in the chain has a property with the given key.
+func HasProperty(err error, key string) bool {
+	return !walk(err, func(err error) bool {
+		_, ok := GetKVs(err)[key]
+		return !ok
+	})
+}
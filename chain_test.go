@@ -0,0 +1,289 @@
+package eris_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	grpc "google.golang.org/grpc/codes"
+
+	"github.com/risingwavelabs/eris"
+)
+
+type kvError struct {
+	kvs map[string]any
+}
+
+func (e kvError) Error() string       { return "kv error" }
+func (e kvError) KVs() map[string]any { return e.kvs }
+
+func TestAllKVs(t *testing.T) {
+	tests := map[string]struct {
+		cause      error
+		precedence eris.Precedence
+		kvs        map[string]any
+	}{
+		"nil error": {
+			cause: nil,
+			kvs:   map[string]any{},
+		},
+		"external error": {
+			cause: errors.New("external error"),
+			kvs:   map[string]any{},
+		},
+		"root property visible after wrapping": {
+			cause: eris.Wrap(eris.Wrap(eris.New("root").WithProperty("k1", "v1"), "wrap1"), "wrap2"),
+			kvs:   map[string]any{"k1": "v1"},
+		},
+		"outermost wins": {
+			cause: eris.WithProperty(eris.Wrap(eris.New("root").WithProperty("k1", "root").WithProperty("k2", 2), "wrap"), "k1", "wrap"),
+			kvs:   map[string]any{"k1": "wrap", "k2": 2},
+		},
+		"innermost wins": {
+			cause:      eris.WithProperty(eris.Wrap(eris.New("root").WithProperty("k1", "root").WithProperty("k2", 2), "wrap"), "k1", "wrap"),
+			precedence: eris.InnermostWins,
+			kvs:        map[string]any{"k1": "root", "k2": 2},
+		},
+		"joined errors": {
+			cause: eris.Join(eris.New("a").WithProperty("k1", 1), eris.New("b").WithProperty("k2", 2)),
+			kvs:   map[string]any{"k1": 1, "k2": 2},
+		},
+		"external kv error": {
+			cause: eris.Wrap(fmt.Errorf("external: %w", kvError{kvs: map[string]any{"k1": "ext"}}), "wrap"),
+			kvs:   map[string]any{"k1": "ext"},
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if kvs := eris.CollectKVs(tc.cause, tc.precedence); !reflect.DeepEqual(kvs, tc.kvs) {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.kvs, kvs)
+			}
+		})
+	}
+}
+
+func TestFindProperty(t *testing.T) {
+	err := eris.Wrap(eris.Wrap(eris.New("root").WithProperty("k1", "v1").WithProperty("k2", 2), "wrap1"), "wrap2")
+
+	if _, ok := eris.GetProperty[string](err, "k1"); ok {
+		t.Errorf("expected GetProperty to only look at the outermost error")
+	}
+	if v, ok := eris.FindProperty[string](err, "k1"); !ok || v != "v1" {
+		t.Errorf("expected { %v } got { %v, %v }", "v1", v, ok)
+	}
+	if v := eris.FindPropertyP[int](err, "k2"); v == nil || *v != 2 {
+		t.Errorf("expected { %v } got { %v }", 2, v)
+	}
+	if v := eris.FindPropertyP[string](err, "k2"); v != nil {
+		t.Errorf("expected type mismatch to return nil, got { %v }", *v)
+	}
+
+	key := eris.NewKey[int]("k3")
+	err = eris.Wrap(eris.WithKey(err, key, 3), "wrap3")
+	if v, ok := key.Find(err); !ok || v != 3 {
+		t.Errorf("expected { %v } got { %v, %v }", 3, v, ok)
+	}
+	if v, ok := eris.FindProperty[int](err, "k3"); !ok || v != 3 {
+		t.Errorf("expected key to be found by name { %v } got { %v, %v }", 3, v, ok)
+	}
+}
+
+type codeError struct {
+	code eris.Code
+}
+
+func (e codeError) Error() string   { return "code error" }
+func (e codeError) Code() eris.Code { return e.code }
+
+func TestEffectiveCode(t *testing.T) {
+	tests := map[string]struct {
+		cause             error
+		outermostExplicit eris.Code
+		innermost         eris.Code
+		mostSevere        eris.Code
+	}{
+		"nil error": {
+			cause:             nil,
+			outermostExplicit: eris.CodeUnknown,
+			innermost:         eris.CodeUnknown,
+			mostSevere:        eris.CodeUnknown,
+		},
+		"external error": {
+			cause:             errors.New("external error"),
+			outermostExplicit: eris.CodeUnknown,
+			innermost:         eris.CodeUnknown,
+			mostSevere:        eris.CodeUnknown,
+		},
+		"defaults only": {
+			cause:             eris.Wrap(eris.New("root"), "wrap"),
+			outermostExplicit: eris.CodeInternal,
+			innermost:         eris.CodeUnknown,
+			mostSevere:        eris.CodeInternal,
+		},
+		"wrap default does not mask root code": {
+			cause:             eris.Wrap(eris.Wrap(eris.New("root").WithCode(eris.CodeNotFound), "wrap1"), "wrap2"),
+			outermostExplicit: eris.CodeNotFound,
+			innermost:         eris.CodeNotFound,
+			mostSevere:        eris.CodeNotFound,
+		},
+		"multiple explicit codes": {
+			cause: eris.WithCode(eris.Wrap(eris.WithCode(eris.Wrap(
+				eris.New("root").WithCode(eris.CodeNotFound), "wrap1"), eris.CodeDataLoss), "wrap2"), eris.CodeInvalidArgument),
+			outermostExplicit: eris.CodeInvalidArgument,
+			innermost:         eris.CodeNotFound,
+			mostSevere:        eris.CodeDataLoss,
+		},
+		"pass through keeps explicit code": {
+			cause:             eris.PassThrough(eris.New("root").WithCode(eris.CodeAborted), "pass"),
+			outermostExplicit: eris.CodeAborted,
+			innermost:         eris.CodeAborted,
+			mostSevere:        eris.CodeAborted,
+		},
+		"external error with code": {
+			cause:             eris.Wrap(fmt.Errorf("external: %w", codeError{code: eris.CodeUnavailable}), "wrap"),
+			outermostExplicit: eris.CodeUnavailable,
+			innermost:         eris.CodeUnavailable,
+			mostSevere:        eris.CodeUnavailable,
+		},
+		"joined errors": {
+			cause:             eris.Join(eris.New("a").WithCode(eris.CodeNotFound), eris.New("b").WithCode(eris.CodeUnavailable)),
+			outermostExplicit: eris.CodeNotFound,
+			innermost:         eris.CodeUnavailable,
+			mostSevere:        eris.CodeUnavailable,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if code := eris.EffectiveCode(tc.cause, eris.OutermostExplicit); code != tc.outermostExplicit {
+				t.Errorf("%v: outermost explicit: expected { %v } got { %v }", desc, tc.outermostExplicit, code)
+			}
+			if code := eris.EffectiveCode(tc.cause, eris.Innermost); code != tc.innermost {
+				t.Errorf("%v: innermost: expected { %v } got { %v }", desc, tc.innermost, code)
+			}
+			if code := eris.EffectiveCode(tc.cause, eris.MostSevere); code != tc.mostSevere {
+				t.Errorf("%v: most severe: expected { %v } got { %v }", desc, tc.mostSevere, code)
+			}
+		})
+	}
+}
+
+func TestHasExplicitCode(t *testing.T) {
+	tests := map[string]struct {
+		cause    error
+		explicit bool
+	}{
+		"external error":      {cause: errors.New("external error")},
+		"external code error": {cause: codeError{code: eris.CodeNotFound}, explicit: true},
+		"new":                 {cause: eris.New("root")},
+		"new with code":       {cause: eris.New("root").WithCode(eris.CodeNotFound), explicit: true},
+		"new with grpc code":  {cause: eris.New("root").WithCodeGrpc(grpc.NotFound), explicit: true},
+		"wrap":                {cause: eris.Wrap(eris.New("root").WithCode(eris.CodeNotFound), "wrap")},
+		"wrap with code":      {cause: eris.WithCode(eris.Wrap(eris.New("root"), "wrap"), eris.CodeNotFound), explicit: true},
+		"global error":        {cause: eris.Wrap(globalErr, "wrap"), explicit: false},
+		"pass through":        {cause: eris.PassThrough(eris.New("root"), "pass")},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if explicit := eris.HasExplicitCode(tc.cause); explicit != tc.explicit {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.explicit, explicit)
+			}
+		})
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	tests := map[string]struct {
+		cause error
+		codes []eris.Code
+		is    bool
+	}{
+		"nil error": {
+			cause: nil,
+			codes: []eris.Code{eris.CodeNotFound},
+		},
+		"outermost code": {
+			cause: eris.New("root").WithCode(eris.CodeNotFound),
+			codes: []eris.Code{eris.CodeNotFound},
+			is:    true,
+		},
+		"outermost default code": {
+			cause: eris.Wrap(eris.New("root"), "wrap"),
+			codes: []eris.Code{eris.CodeInternal},
+			is:    true,
+		},
+		"default code masking explicit code": {
+			cause: eris.Wrap(eris.New("root").WithCode(eris.CodeNotFound), "wrap"),
+			codes: []eris.Code{eris.CodeInternal},
+		},
+		"root code below wrap": {
+			cause: eris.Wrap(eris.Wrap(eris.New("root").WithCode(eris.CodeNotFound), "wrap1"), "wrap2"),
+			codes: []eris.Code{eris.CodeNotFound},
+			is:    true,
+		},
+		"default code below wrap": {
+			cause: eris.WithCode(eris.Wrap(eris.Wrap(eris.New("root"), "wrap1"), "wrap2"), eris.CodeNotFound),
+			codes: []eris.Code{eris.CodeInternal},
+		},
+		"one of many codes": {
+			cause: eris.New("root").WithCode(eris.CodeAborted),
+			codes: []eris.Code{eris.CodeUnavailable, eris.CodeAborted},
+			is:    true,
+		},
+		"joined error": {
+			cause: eris.Join(errors.New("external"), eris.New("root").WithCode(eris.CodeNotFound)),
+			codes: []eris.Code{eris.CodeNotFound},
+			is:    true,
+		},
+		"no match": {
+			cause: eris.New("root").WithCode(eris.CodeAborted),
+			codes: []eris.Code{eris.CodeNotFound},
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if is := eris.IsCode(tc.cause, tc.codes...); is != tc.is {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.is, is)
+			}
+		})
+	}
+}
+
+func TestHasProperty(t *testing.T) {
+	err := eris.Wrap(eris.Join(errors.New("external"), eris.New("root").WithProperty("k1", nil)), "wrap")
+	if !eris.HasProperty(err, "k1") {
+		t.Errorf("expected property { %v } to be found in { %v }", "k1", err)
+	}
+	if eris.HasProperty(err, "k2") {
+		t.Errorf("expected property { %v } not to be found in { %v }", "k2", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := eris.New("root").WithCode(eris.CodeNotFound).WithProperty("table", "t1")
+	err := eris.Wrap(eris.Join(errors.New("external"), eris.Wrap(root, "wrap1")), "wrap2")
+
+	view, ok := eris.Find(err, func(view eris.ErrView) bool {
+		return view.Code == eris.CodeNotFound
+	})
+	if !ok {
+		t.Fatalf("expected to find an error with code { %v } in { %v }", eris.CodeNotFound, err)
+	}
+	if view.Msg != "root" || !view.Explicit || view.KVs["table"] != "t1" {
+		t.Errorf("expected root error view, got { %+v }", view)
+	}
+	if view.Frame.Name != "eris_test.TestFind" {
+		t.Errorf("expected frame { %v } got { %v }", "eris_test.TestFind", view.Frame.Name)
+	}
+
+	view, ok = eris.Find(err, func(view eris.ErrView) bool {
+		return view.Msg == "external"
+	})
+	if !ok || view.Code != eris.CodeUnknown || view.Frame != (eris.StackFrame{}) {
+		t.Errorf("expected external error view, got { %+v }", view)
+	}
+
+	if _, ok := eris.Find(err, func(view eris.ErrView) bool { return false }); ok {
+		t.Errorf("expected no match")
+	}
+}
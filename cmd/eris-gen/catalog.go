@@ -0,0 +1,190 @@
+package main
+
+import (
+	"go/token"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/risingwavelabs/eris"
+)
+
+// Catalog is the declaration of all errors of a package.
+type Catalog struct {
+	Package string  `yaml:"package"`
+	Errors  []Entry `yaml:"errors"`
+}
+
+// Entry is the declaration of a single error kind.
+type Entry struct {
+	ID         string  `yaml:"id"`
+	Code       string  `yaml:"code"`
+	Message    string  `yaml:"message"`
+	Params     []Param `yaml:"params"`
+	Hint       string  `yaml:"hint"`
+	HTTPStatus int     `yaml:"http_status"`
+	SQLState   string  `yaml:"sqlstate"`
+	DocURL     string  `yaml:"doc_url"`
+}
+
+// Param is a typed parameter of a message template, referenced as {name} in the message.
+type Param struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+var (
+	idPattern          = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	paramPattern       = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	placeholderPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+)
+
+// reservedNames are the identifiers used by the generated code, which can't be used as parameter names.
+var reservedNames = map[string]bool{
+	"err":  true,
+	"eris": true,
+}
+
+// paramTypes are the supported Go types of parameters.
+var paramTypes = map[string]bool{
+	"string":  true,
+	"int":     true,
+	"int64":   true,
+	"uint64":  true,
+	"float64": true,
+	"bool":    true,
+	"any":     true,
+}
+
+// codeIdents maps the codes to the names of their constants.
+var codeIdents = map[eris.Code]string{
+	eris.CodeCanceled:           "CodeCanceled",
+	eris.CodeUnknown:            "CodeUnknown",
+	eris.CodeInvalidArgument:    "CodeInvalidArgument",
+	eris.CodeDeadlineExceeded:   "CodeDeadlineExceeded",
+	eris.CodeNotFound:           "CodeNotFound",
+	eris.CodeAlreadyExists:      "CodeAlreadyExists",
+	eris.CodePermissionDenied:   "CodePermissionDenied",
+	eris.CodeResourceExhausted:  "CodeResourceExhausted",
+	eris.CodeFailedPrecondition: "CodeFailedPrecondition",
+	eris.CodeAborted:            "CodeAborted",
+	eris.CodeOutOfRange:         "CodeOutOfRange",
+	eris.CodeUnimplemented:      "CodeUnimplemented",
+	eris.CodeInternal:           "CodeInternal",
+	eris.CodeUnavailable:        "CodeUnavailable",
+	eris.CodeDataLoss:           "CodeDataLoss",
+	eris.CodeUnauthenticated:    "CodeUnauthenticated",
+}
+
+// parseCatalog parses a catalog in YAML or JSON format and validates it.
+func parseCatalog(data []byte) (*Catalog, error) {
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, eris.WithCode(eris.Wrap(err, "failed to parse catalog"), eris.CodeInvalidArgument)
+	}
+	if err := catalog.validate(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// validate checks that all entries are complete and consistent.
+func (c *Catalog) validate() error {
+	if c.Package == "" {
+		return eris.New("catalog has no package").WithCode(eris.CodeInvalidArgument)
+	}
+	if !token.IsIdentifier(c.Package) {
+		return eris.Errorf("invalid package name %q", c.Package).WithCode(eris.CodeInvalidArgument)
+	}
+	names := make(map[string]string) // IDs by Go name
+	for _, entry := range c.Errors {
+		if !idPattern.MatchString(entry.ID) {
+			return eris.Errorf("invalid error ID %q, expected upper snake case", entry.ID).
+				WithCode(eris.CodeInvalidArgument)
+		}
+		name := goName(entry.ID)
+		if other, ok := names[name]; ok {
+			if other == entry.ID {
+				return eris.Errorf("duplicate error ID %q", entry.ID).WithCode(eris.CodeInvalidArgument)
+			}
+			return eris.Errorf("error IDs %q and %q both result in Go name %q", other, entry.ID, name).
+				WithCode(eris.CodeInvalidArgument)
+		}
+		names[name] = entry.ID
+		if err := entry.validate(); err != nil {
+			return eris.WithProperty(eris.Wrapf(err, "invalid error %v", entry.ID), "id", entry.ID)
+		}
+	}
+	return nil
+}
+
+// validate checks the code, the parameters and the message template of an entry.
+func (e *Entry) validate() error {
+	if _, ok := lookupCode(e.Code); !ok {
+		return eris.Errorf("unknown code %q", e.Code).WithCode(eris.CodeInvalidArgument)
+	}
+	if e.Message == "" {
+		return eris.New("message is empty").WithCode(eris.CodeInvalidArgument)
+	}
+	params := make(map[string]bool)
+	for _, param := range e.Params {
+		if !paramPattern.MatchString(param.Name) || token.IsKeyword(param.Name) || reservedNames[param.Name] {
+			return eris.Errorf("invalid parameter name %q", param.Name).WithCode(eris.CodeInvalidArgument)
+		}
+		if params[param.Name] {
+			return eris.Errorf("duplicate parameter %q", param.Name).WithCode(eris.CodeInvalidArgument)
+		}
+		if !paramTypes[param.Type] {
+			return eris.Errorf("unsupported type %q of parameter %q", param.Type, param.Name).
+				WithCode(eris.CodeInvalidArgument)
+		}
+		params[param.Name] = true
+	}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(e.Message, -1) {
+		if !params[match[1]] {
+			return eris.Errorf("message references undeclared parameter %q", match[1]).
+				WithCode(eris.CodeInvalidArgument)
+		}
+	}
+	if e.HTTPStatus != 0 && (e.HTTPStatus < 100 || e.HTTPStatus > 599) {
+		return eris.Errorf("invalid HTTP status %v", e.HTTPStatus).WithCode(eris.CodeInvalidArgument)
+	}
+	return nil
+}
+
+// lookupCode returns the code with the given name. Names are matched case-insensitively and ignoring spaces
+// and underscores, so "not found", "NOT_FOUND" and "NotFound" are equivalent.
+func lookupCode(name string) (eris.Code, bool) {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(s))
+	}
+	for code := range codeIdents {
+		if normalize(code.String()) == normalize(name) {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// format returns the message template as format string and the names of its arguments in order.
+func (e *Entry) format() (string, []string) {
+	var args []string
+	format := placeholderPattern.ReplaceAllStringFunc(strings.ReplaceAll(e.Message, "%", "%%"), func(match string) string {
+		args = append(args, match[1:len(match)-1])
+		return "%v"
+	})
+	return format, args
+}
+
+// goName converts an upper snake case ID into a Go identifier, e.g. TABLE_NOT_FOUND into TableNotFound.
+func goName(id string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(strings.ToLower(id), "_") {
+		if part == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
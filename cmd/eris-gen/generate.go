@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/risingwavelabs/eris"
+)
+
+// goTemplate renders the Go constructors of a catalog.
+var goTemplate = template.Must(template.New("go").Parse(`// Code generated by eris-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import "github.com/risingwavelabs/eris"
+
+{{ range .Errors }}
+// {{ .Var }} declares {{ .ID }} errors.
+var {{ .Var }} = eris.Define({{ printf "%q" .ID }}, eris.{{ .CodeIdent }}, {{ printf "%q" .Format }})
+
+// New{{ .Name }} creates a new {{ .ID }} error.
+func New{{ .Name }}({{ .Params }}) error {
+	return eris.With({{ .Var }}.New({{ .Args }}){{ .FieldArgs }})
+}
+
+// Wrap{{ .Name }} wraps an error as {{ .ID }} error.
+func Wrap{{ .Name }}(err error{{ if .Params }}, {{ .Params }}{{ end }}) error {
+	return eris.With({{ .Var }}.Wrap(err{{ if .Args }}, {{ .Args }}{{ end }}){{ .FieldArgs }})
+}
+{{ end }}`))
+
+// markdownTemplate renders the reference page of a catalog.
+var markdownTemplate = template.Must(template.New("md").Parse(`# Errors of package {{ .Package }}
+
+<!-- Code generated by eris-gen. DO NOT EDIT. -->
+
+| ID | Code | HTTP status | SQLSTATE | Message |
+| --- | --- | --- | --- | --- |
+{{ range .Errors }}| [{{ .ID }}](#{{ .Anchor }}) | {{ .Code }} | {{ .HTTPStatus }} | {{ .SQLState }} | {{ .TableMessage }} |
+{{ end }}{{ range .Errors }}
+## {{ .ID }}
+
+{{ .Message }}
+
+- Code: ` + "`{{ .Code }}`" + `
+- HTTP status: {{ .HTTPStatus }}
+{{- if .SQLState }}
+- SQLSTATE: ` + "`{{ .SQLState }}`" + `
+{{- end }}
+{{- if .ParamDocs }}
+- Parameters: {{ .ParamDocs }}
+{{- end }}
+{{- if .Hint }}
+- Hint: {{ .Hint }}
+{{- end }}
+{{- if .DocURL }}
+- Documentation: <{{ .DocURL }}>
+{{- end }}
+{{ end }}`))
+
+// templateData is the input of the templates.
+type templateData struct {
+	Package string
+	Errors  []templateEntry
+}
+
+// templateEntry is the input of the templates for a single error.
+type templateEntry struct {
+	ID           string
+	Name         string
+	Var          string
+	Code         string
+	CodeIdent    string
+	Message      string
+	TableMessage string
+	Format       string
+	Params       string
+	Args         string
+	FieldArgs    string
+	ParamDocs    string
+	Hint         string
+	HTTPStatus   int
+	SQLState     string
+	DocURL       string
+	Anchor       string
+}
+
+// newTemplateData prepares a validated catalog for the templates.
+func newTemplateData(c *Catalog) templateData {
+	data := templateData{Package: c.Package}
+	for _, e := range c.Errors {
+		code, _ := lookupCode(e.Code)
+		format, args := e.format()
+		entry := templateEntry{
+			ID:           e.ID,
+			Name:         goName(e.ID),
+			Var:          "Err" + goName(e.ID),
+			Code:         code.String(),
+			CodeIdent:    codeIdents[code],
+			Message:      e.Message,
+			TableMessage: strings.ReplaceAll(e.Message, "|", "\\|"),
+			Format:       format,
+			Args:         strings.Join(args, ", "),
+			Hint:         e.Hint,
+			HTTPStatus:   e.HTTPStatus,
+			SQLState:     e.SQLState,
+			DocURL:       e.DocURL,
+			Anchor:       strings.ToLower(e.ID),
+		}
+		if entry.HTTPStatus == 0 {
+			entry.HTTPStatus = int(code.ToHttp())
+		}
+
+		var params, paramDocs, fields []string
+		for _, p := range e.Params {
+			params = append(params, p.Name+" "+p.Type)
+			paramDocs = append(paramDocs, fmt.Sprintf("`%s %s`", p.Name, p.Type))
+			fields = append(fields, fmt.Sprintf("eris.KVs(%q, %s)", p.Name, p.Name))
+		}
+		if e.Hint != "" {
+			fields = append(fields, fmt.Sprintf("eris.Hints(%q)", e.Hint))
+		}
+		if e.DocURL != "" {
+			fields = append(fields, fmt.Sprintf("eris.DocURLs(%q)", e.DocURL))
+		}
+		if e.SQLState != "" {
+			fields = append(fields, fmt.Sprintf("eris.KVs(%q, %q)", "sqlstate", e.SQLState))
+		}
+		if e.HTTPStatus != 0 {
+			fields = append(fields, fmt.Sprintf("eris.HTTPStatuses(%d)", e.HTTPStatus))
+		}
+		entry.Params = strings.Join(params, ", ")
+		entry.ParamDocs = strings.Join(paramDocs, ", ")
+		if len(fields) > 0 {
+			entry.FieldArgs = ", " + strings.Join(fields, ", ")
+		}
+		data.Errors = append(data.Errors, entry)
+	}
+	return data
+}
+
+// generateGo renders the Go constructors of a catalog.
+func generateGo(c *Catalog) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, newTemplateData(c)); err != nil {
+		return nil, eris.Wrap(err, "failed to render Go code")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to format Go code")
+	}
+	return src, nil
+}
+
+// generateMarkdown renders the reference page of a catalog.
+func generateMarkdown(c *Catalog) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := markdownTemplate.Execute(&buf, newTemplateData(c)); err != nil {
+		return nil, eris.Wrap(err, "failed to render Markdown")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestParseCatalog(t *testing.T) {
+	tests := map[string]struct {
+		catalog string
+		err     string
+	}{
+		"json": {
+			catalog: `{"package": "errs", "errors": [{"id": "NOT_FOUND", "code": "not found", "message": "not found"}]}`,
+		},
+		"no package": {
+			catalog: `errors: []`,
+			err:     "catalog has no package",
+		},
+		"invalid package": {
+			catalog: `{"package": "my-errors", "errors": []}`,
+			err:     `invalid package name "my-errors"`,
+		},
+		"invalid ID": {
+			catalog: "package: errs\nerrors:\n  - id: notFound\n    code: not found\n    message: msg",
+			err:     `invalid error ID "notFound"`,
+		},
+		"duplicate ID": {
+			catalog: "package: errs\nerrors:\n  - id: A\n    code: internal\n    message: a\n  - id: A\n    code: internal\n    message: a",
+			err:     `duplicate error ID "A"`,
+		},
+		"colliding Go names": {
+			catalog: "package: errs\nerrors:\n  - id: A_B\n    code: internal\n    message: a\n  - id: A__B\n    code: internal\n    message: a",
+			err:     `error IDs "A_B" and "A__B" both result in Go name "AB"`,
+		},
+		"unknown code": {
+			catalog: "package: errs\nerrors:\n  - id: A\n    code: teapot\n    message: a",
+			err:     `unknown code "teapot"`,
+		},
+		"undeclared parameter": {
+			catalog: "package: errs\nerrors:\n  - id: A\n    code: internal\n    message: '{name} failed'",
+			err:     `undeclared parameter "name"`,
+		},
+		"unsupported type": {
+			catalog: "package: errs\nerrors:\n  - id: A\n    code: internal\n    message: a\n    params:\n      - name: t\n        type: chan int",
+			err:     `unsupported type "chan int"`,
+		},
+		"reserved parameter": {
+			catalog: "package: errs\nerrors:\n  - id: A\n    code: internal\n    message: a\n    params:\n      - name: err\n        type: string",
+			err:     `invalid parameter name "err"`,
+		},
+		"invalid HTTP status": {
+			catalog: "package: errs\nerrors:\n  - id: A\n    code: internal\n    message: a\n    http_status: 42",
+			err:     "invalid HTTP status 42",
+		},
+		"malformed": {
+			catalog: "package: [",
+			err:     "failed to parse catalog",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			_, err := parseCatalog([]byte(tc.catalog))
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("%v: expected no error got { %v }", desc, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%v: expected error containing { %v } got { %v }", desc, tc.err, err)
+			}
+			if !eris.IsCode(err, eris.CodeInvalidArgument) {
+				t.Errorf("%v: expected code { %v } got { %v }", desc, eris.CodeInvalidArgument, eris.GetCode(err))
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	out, doc := filepath.Join(dir, "errors_gen.go"), filepath.Join(dir, "errors.md")
+	if err := run("testdata/catalog.yaml", out, doc); err != nil {
+		t.Fatalf("expected no error got { %v }", err)
+	}
+
+	src, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, src)
+	for _, expected := range []string{
+		"package catalog",
+		`var ErrTableNotFound = eris.Define("TABLE_NOT_FOUND", eris.CodeNotFound, "table \"%v\" not found in schema \"%v\"")`,
+		"func NewTableNotFound(table string, schema string) error {",
+		"func WrapTableNotFound(err error, table string, schema string) error {",
+		`eris.KVs("table", table), eris.KVs("schema", schema), eris.Hints("check the spelling of the table name")`,
+		`eris.KVs("sqlstate", "42P01")`,
+		"func NewDivisionByZero() error {",
+		`"quota of %v rows exceeded (100%% used)"`,
+		`eris.With(ErrQuotaExceeded.New(limit), eris.KVs("limit", limit), eris.HTTPStatuses(503))`,
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated code to contain { %v } got\n%s", expected, src)
+		}
+	}
+
+	md, err := os.ReadFile(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`| [TABLE_NOT_FOUND](#table_not_found) | not found | 404 | 42P01 | table "{table}" not found in schema "{schema}" |`,
+		"| [QUOTA_EXCEEDED](#quota_exceeded) | resource exhausted | 503 |  |",
+		"- Documentation: <https://docs.example.com/errors/table-not-found>",
+	} {
+		if !strings.Contains(string(md), expected) {
+			t.Errorf("expected reference page to contain { %v } got\n%s", expected, md)
+		}
+	}
+}
+
+// typeCheck vets the generated code in a temporary module that uses the eris module of this repository, so wrong
+// identifiers or imports fail the test.
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping type check of generated code in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("skipping type check of generated code, go tool not found")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	mod := fmt.Sprintf("module example.com/generated\n\ngo 1.21\n\nrequire github.com/risingwavelabs/eris v0.0.0\n\nreplace github.com/risingwavelabs/eris => %s\n", root)
+	for name, data := range map[string][]byte{"go.mod": []byte(mod), "go.sum": sum, "errors_gen.go": src} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goTool, "vet", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("expected generated code to type-check got { %v }\n%s", err, out)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"TABLE_NOT_FOUND": "TableNotFound",
+		"A":               "A",
+		"HTTP2_ERROR":     "Http2Error",
+	}
+	for id, expected := range tests {
+		if name := goName(id); name != expected {
+			t.Errorf("%v: expected { %v } got { %v }", id, expected, name)
+		}
+	}
+}
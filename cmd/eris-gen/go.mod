@@ -0,0 +1,10 @@
+module github.com/risingwavelabs/eris/cmd/eris-gen
+
+go 1.21
+
+require (
+	github.com/risingwavelabs/eris v0.1.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require google.golang.org/grpc v1.53.0 // indirect
@@ -0,0 +1,77 @@
+// Command eris-gen generates Go constructors and a Markdown reference page from an error catalog.
+//
+// The catalog is a YAML or JSON file declaring each error once, with a stable ID, a code and a message template
+// whose typed parameters are referenced as {name}:
+//
+//	package: catalog
+//	errors:
+//	  - id: TABLE_NOT_FOUND
+//	    code: not found
+//	    message: table "{table}" not found
+//	    params:
+//	      - name: table
+//	        type: string
+//	    hint: check the spelling of the table name
+//	    http_status: 404
+//	    sqlstate: 42P01
+//	    doc_url: https://docs.example.com/errors/table-not-found
+//
+// For each error, eris-gen generates an eris.Definition and the constructors NewTableNotFound(table string) and
+// WrapTableNotFound(err error, table string). Parameters are attached as properties, the SQLSTATE as property
+// "sqlstate" and the HTTP status as override for eris.ToHTTP.
+//
+// Usage:
+//
+//	eris-gen -catalog errors.yaml -out errors_gen.go -doc errors.md
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func main() {
+	catalogPath := flag.String("catalog", "errors.yaml", "path of the YAML or JSON catalog")
+	outPath := flag.String("out", "errors_gen.go", "path of the generated Go file")
+	docPath := flag.String("doc", "", "path of the generated Markdown reference page, skipped if empty")
+	flag.Parse()
+
+	if err := run(*catalogPath, *outPath, *docPath); err != nil {
+		fmt.Fprintln(os.Stderr, eris.ToString(err, false))
+		os.Exit(1)
+	}
+}
+
+// run generates the Go constructors and, if docPath is set, the reference page of a catalog.
+func run(catalogPath, outPath, docPath string) error {
+	data, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return eris.Wrapf(err, "failed to read catalog %v", catalogPath)
+	}
+	catalog, err := parseCatalog(data)
+	if err != nil {
+		return eris.Wrapf(err, "invalid catalog %v", catalogPath)
+	}
+
+	src, err := generateGo(catalog)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, src, 0o644); err != nil {
+		return eris.Wrapf(err, "failed to write %v", outPath)
+	}
+	if docPath == "" {
+		return nil
+	}
+	doc, err := generateMarkdown(catalog)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(docPath, doc, 0o644); err != nil {
+		return eris.Wrapf(err, "failed to write %v", docPath)
+	}
+	return nil
+}
@@ -71,6 +71,61 @@ var defaultErrorCodes = map[Code]string{
 	CodeUnimplemented:      "unimplemented",
 }
 
+// codeSeverity ranks the codes by severity, higher is more severe. Server side failures rank above errors
+// caused by the client.
+var codeSeverity = map[Code]int{
+	CodeCanceled:           1,
+	CodeInvalidArgument:    2,
+	CodeNotFound:           3,
+	CodeAlreadyExists:      4,
+	CodeUnauthenticated:    5,
+	CodePermissionDenied:   6,
+	CodeOutOfRange:         7,
+	CodeFailedPrecondition: 8,
+	CodeAborted:            9,
+	CodeResourceExhausted:  10,
+	CodeDeadlineExceeded:   11,
+	CodeUnimplemented:      12,
+	CodeUnavailable:        13,
+	CodeUnknown:            14,
+	CodeInternal:           15,
+	CodeDataLoss:           16,
+}
+
+// MoreSevere returns true if c is more severe than other.
+func (c Code) MoreSevere(other Code) bool {
+	return codeSeverity[c] > codeSeverity[other]
+}
+
+// Severity returns the default severity of errors with code c. Errors caused by the client are info, transient
+// errors warning, and data loss critical.
+func (c Code) Severity() Severity {
+	switch c {
+	case CodeCanceled:
+		return SeverityDebug
+	case CodeInvalidArgument, CodeNotFound, CodeAlreadyExists, CodePermissionDenied, CodeUnauthenticated,
+		CodeFailedPrecondition, CodeOutOfRange:
+		return SeverityInfo
+	case CodeAborted, CodeResourceExhausted, CodeDeadlineExceeded, CodeUnavailable, CodeUnimplemented:
+		return SeverityWarning
+	case CodeDataLoss:
+		return SeverityCritical
+	default:
+		return SeverityError
+	}
+}
+
+// Retryable returns true if an operation failing with c can be retried, as described in the documentation of the
+// codes: 'unavailable' can be retried with a backoff, 'aborted' and 'resource exhausted' after a while.
+func (c Code) Retryable() bool {
+	switch c {
+	case CodeUnavailable, CodeAborted, CodeResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
 const (
 	// Default error code assigned when using eris.New.
 	DEFAULT_ERROR_CODE_NEW = CodeUnknown
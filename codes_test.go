@@ -32,3 +32,34 @@ func TestInvalidConversions(t *testing.T) {
 		t.Errorf("http 200 should not get converted to our error codes, but was converted to %v", code)
 	}
 }
+
+func TestCodeSeverity(t *testing.T) {
+	for code := range defaultErrorCodes {
+		if _, ok := codeSeverity[code]; !ok {
+			t.Errorf("code %v has no severity rank", code)
+		}
+	}
+	if !CodeDataLoss.MoreSevere(CodeInternal) {
+		t.Errorf("data loss should be more severe than internal")
+	}
+	if CodeNotFound.MoreSevere(CodeUnavailable) {
+		t.Errorf("not found should not be more severe than unavailable")
+	}
+}
+
+func TestCodeRetryable(t *testing.T) {
+	retryable := map[Code]bool{CodeUnavailable: true, CodeAborted: true, CodeResourceExhausted: true}
+	for code := range defaultErrorCodes {
+		if code.Retryable() != retryable[code] {
+			t.Errorf("code %v: expected retryable { %v } got { %v }", code, retryable[code], code.Retryable())
+		}
+	}
+}
+
+func TestDefaultPublicMessages(t *testing.T) {
+	for code := range defaultErrorCodes {
+		if _, ok := defaultPublicMessages[code]; !ok {
+			t.Errorf("code %v has no default public message", code)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package eris
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ContextExtractor returns fields of request-scoped values stored in a context, e.g. a trace ID or a tenant.
+type ContextExtractor func(ctx context.Context) []Field
+
+var (
+	contextExtractorsMu sync.RWMutex
+	contextExtractors   []ContextExtractor
+)
+
+// RegisterContextExtractor registers an extractor whose fields are attached to all errors created with a
+// context, e.g. via WrapCtx or FromContext. Extractors are called in the order they were registered.
+func RegisterContextExtractor(extractor ContextExtractor) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// contextFieldsKey is the context key of the fields attached via ContextWithFields.
+type contextFieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx that carries the given fields in addition to the fields already
+// attached to ctx. The fields are applied to all errors created via FromContext, WrapCtx and WrapfCtx.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	parent, _ := ctx.Value(contextFieldsKey{}).([]Field)
+	merged := make([]Field, 0, len(parent)+len(fields))
+	merged = append(merged, parent...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// contextFields returns the fields of all registered extractors followed by the fields attached via
+// ContextWithFields, so attached fields win over extracted ones.
+func contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []Field
+	contextExtractorsMu.RLock()
+	for _, extractor := range contextExtractors {
+		fields = append(fields, extractor(ctx)...)
+	}
+	contextExtractorsMu.RUnlock()
+	attached, _ := ctx.Value(contextFieldsKey{}).([]Field)
+	return append(fields, attached...)
+}
+
+// Builder creates errors that carry the fields of a context, see FromContext.
+type Builder struct {
+	fields []Field
+}
+
+// FromContext returns a builder for errors that carry the fields attached to ctx via ContextWithFields and the
+// fields of all registered context extractors.
+//
+//	ctx = eris.ContextWithFields(ctx, eris.KVs("query_id", id))
+//	...
+//	return eris.FromContext(ctx).Wrap(err, "failed to scan table")
+func FromContext(ctx context.Context) Builder {
+	return Builder{fields: contextFields(ctx)}
+}
+
+// New creates a new root error like New and applies the fields of the builder.
+func (b Builder) New(msg string) statusError {
+	stack := callers(3)
+	root := &rootError{
+		global: stack.isGlobal(),
+		msg:    msg,
+		stack:  stack,
+		code:   DEFAULT_ERROR_CODE_NEW,
+	}
+	created := b.apply(root)
+	notifyCreate(OpNew, created)
+	return created
+}
+
+// Errorf creates a new root error like Errorf and applies the fields of the builder.
+func (b Builder) Errorf(format string, args ...any) statusError {
+	stack := callers(3)
+	root := &rootError{
+		global: stack.isGlobal(),
+		msg:    formatMsg(format, args, RedactMask),
+		format: format,
+		args:   args,
+		vars:   newMsgVariants(format, args),
+		stack:  stack,
+		code:   DEFAULT_ERROR_CODE_NEW,
+	}
+	created := b.apply(root)
+	notifyCreate(OpNew, created)
+	return created
+}
+
+// Wrap wraps an error like Wrap and applies the fields of the builder.
+func (b Builder) Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return With(wrap(err, msg, "", nil, contextCode(err, DEFAULT_ERROR_CODE_WRAP)), b.fields...)
+}
+
+// Wrapf wraps an error like Wrapf and applies the fields of the builder.
+func (b Builder) Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	newErr := wrap(err, formatMsg(format, args, RedactMask), format, args, contextCode(err, DEFAULT_ERROR_CODE_WRAP))
+	return With(newErr, b.fields...)
+}
+
+// apply applies the fields of the builder to a new root error.
+func (b Builder) apply(root *rootError) statusError {
+	for _, field := range b.fields {
+		root = root.WithField(field).(*rootError)
+	}
+	return root
+}
+
+// contextCode returns the code of a new link wrapping err. If no error in the chain has an explicit code,
+// `context.Canceled` maps to 'canceled' and `context.DeadlineExceeded` to 'deadline exceeded'. Otherwise the
+// given default code is returned.
+func contextCode(err error, code Code) Code {
+	if _, ok := explicitCode(err); ok {
+		return code
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	default:
+		return code
+	}
+}
+
+// contextCauseFields returns a field with the cause of the cancellation of ctx, if the cause differs from
+// ctx.Err(), e.g. if the context was canceled via a `context.CancelCauseFunc`.
+func contextCauseFields(ctx context.Context) []Field {
+	if ctx == nil || ctx.Err() == nil {
+		return nil
+	}
+	cause := context.Cause(ctx)
+	if cause == nil || cause == ctx.Err() {
+		return nil
+	}
+	return []Field{KVs("cause", cause)}
+}
+
+// WrapCtx behaves like Wrap and additionally attaches the fields of ctx, see FromContext. If ctx is done and was
+// canceled with a cause, the cause is attached as property "cause".
+func WrapCtx(ctx context.Context, err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	newErr := wrap(err, msg, "", nil, contextCode(err, DEFAULT_ERROR_CODE_WRAP))
+	return With(newErr, append(contextCauseFields(ctx), contextFields(ctx)...)...)
+}
+
+// WrapfCtx behaves like Wrapf and additionally attaches the fields of ctx, see FromContext. If ctx is done and
+// was canceled with a cause, the cause is attached as property "cause".
+func WrapfCtx(ctx context.Context, err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	newErr := wrap(err, formatMsg(format, args, RedactMask), format, args, contextCode(err, DEFAULT_ERROR_CODE_WRAP))
+	return With(newErr, append(contextCauseFields(ctx), contextFields(ctx)...)...)
+}
@@ -0,0 +1,148 @@
+package eris_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+type requestIDKey struct{}
+
+func init() {
+	eris.RegisterContextExtractor(func(ctx context.Context) []eris.Field {
+		id, ok := ctx.Value(requestIDKey{}).(string)
+		if !ok {
+			return nil
+		}
+		return []eris.Field{eris.KVs("request_id", id)}
+	})
+}
+
+func TestWrapContextErrors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code eris.Code
+	}{
+		"canceled": {
+			err:  eris.Wrap(context.Canceled, "query aborted"),
+			code: eris.CodeCanceled,
+		},
+		"deadline exceeded": {
+			err:  eris.Wrapf(fmt.Errorf("read: %w", context.DeadlineExceeded), "query %v timed out", 1),
+			code: eris.CodeDeadlineExceeded,
+		},
+		"wrapped twice": {
+			err:  eris.Wrap(eris.Wrap(context.Canceled, "inner"), "outer"),
+			code: eris.CodeCanceled,
+		},
+		"pass through": {
+			err:  eris.PassThrough(context.DeadlineExceeded, "pass"),
+			code: eris.CodeDeadlineExceeded,
+		},
+		"explicit code wins": {
+			err:  eris.Wrap(eris.WithCode(context.Canceled, eris.CodeUnavailable), "outer"),
+			code: eris.CodeInternal,
+		},
+		"other error": {
+			err:  eris.Wrap(errors.New("external"), "outer"),
+			code: eris.CodeInternal,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if code := eris.GetCode(tc.err); code != tc.code {
+				t.Errorf("%v: expected code { %v } got { %v }", desc, tc.code, code)
+			}
+		})
+	}
+}
+
+func TestWrapCtx(t *testing.T) {
+	if err := eris.WrapCtx(context.Background(), nil, "nil"); err != nil {
+		t.Errorf("expected nil error got { %v }", err)
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	ctx, cancel := context.WithCancelCause(ctx)
+	shutdown := eris.New("shutting down").WithCode(eris.CodeUnavailable)
+	cancel(shutdown)
+
+	err := eris.WrapfCtx(ctx, ctx.Err(), "query %v aborted", 1)
+	if code := eris.GetCode(err); code != eris.CodeCanceled {
+		t.Errorf("expected code { %v } got { %v }", eris.CodeCanceled, code)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context error to be reachable via Is")
+	}
+	if id, _ := eris.GetProperty[string](err, "request_id"); id != "req-1" {
+		t.Errorf("expected request ID { %v } got { %v }", "req-1", id)
+	}
+	if cause, _ := eris.GetProperty[error](err, "cause"); cause != shutdown {
+		t.Errorf("expected cause { %v } got { %v }", shutdown, cause)
+	}
+	if frame := eris.Unpack(err).ErrRoot.Stack[0]; frame.Name != "eris_test.TestWrapCtx" {
+		t.Errorf("expected stack to start at { %v } got { %v }", "eris_test.TestWrapCtx", frame.Name)
+	}
+
+	err = eris.WrapCtx(context.Background(), eris.New("failure"), "wrap")
+	if eris.HasProperty(err, "cause") || eris.HasProperty(err, "request_id") {
+		t.Errorf("expected no context properties got { %v }", eris.AllKVs(err))
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	ctx = eris.ContextWithFields(ctx, eris.KVs("query_id", 7), eris.KVs("shard", 1))
+	ctx = eris.ContextWithFields(ctx, eris.KVs("shard", 2), eris.KVs("request_id", "req-2"))
+
+	tests := map[string]struct {
+		err   error
+		frame string
+		code  eris.Code
+	}{
+		"new": {
+			err:   eris.FromContext(ctx).New("scan failed"),
+			frame: "eris_test.TestFromContext",
+			code:  eris.CodeUnknown,
+		},
+		"errorf": {
+			err:   eris.FromContext(ctx).Errorf("scan of %v failed", "t1").WithCode(eris.CodeNotFound),
+			frame: "eris_test.TestFromContext",
+			code:  eris.CodeNotFound,
+		},
+		"wrap": {
+			err:   eris.FromContext(ctx).Wrap(errors.New("external"), "scan failed"),
+			frame: "eris_test.TestFromContext",
+			code:  eris.CodeInternal,
+		},
+		"wrapf": {
+			err:   eris.FromContext(ctx).Wrapf(context.Canceled, "scan of %v failed", "t1"),
+			frame: "eris_test.TestFromContext",
+			code:  eris.CodeCanceled,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			kvs := eris.AllKVs(tc.err)
+			if kvs["query_id"] != 7 || kvs["shard"] != 2 || kvs["request_id"] != "req-2" {
+				t.Errorf("%v: expected context fields got { %v }", desc, kvs)
+			}
+			if code := eris.GetCode(tc.err); code != tc.code {
+				t.Errorf("%v: expected code { %v } got { %v }", desc, tc.code, code)
+			}
+			if frame := eris.Unpack(tc.err).ErrRoot.Stack[0]; frame.Name != tc.frame {
+				t.Errorf("%v: expected stack to start at { %v } got { %v }", desc, tc.frame, frame.Name)
+			}
+		})
+	}
+
+	if err := eris.FromContext(ctx).Wrap(nil, "nil"); err != nil {
+		t.Errorf("expected nil error got { %v }", err)
+	}
+	if err := eris.FromContext(context.Background()).New("plain"); err.HasKVs() {
+		t.Errorf("expected no properties got { %v }", err.KVs())
+	}
+}
@@ -0,0 +1,162 @@
+package eris
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	domainsMu sync.RWMutex
+	domains   = map[string]string{}
+)
+
+// Domains returns a Field of DomainType.
+func Domains(domain string) Field {
+	return Field{
+		Type:  DomainType,
+		Value: domain,
+	}
+}
+
+// Components returns a Field of ComponentType.
+func Components(component string) Field {
+	return Field{
+		Type:  ComponentType,
+		Value: component,
+	}
+}
+
+// WithDomain tags an error with the domain that owns it, e.g. "storage". Like hints, domains and components are
+// stored separately from the KVs.
+func WithDomain(err error, domain string) error {
+	return With(err, Domains(domain))
+}
+
+// WithComponent tags an error with the component that raised it, e.g. "compactor".
+func WithComponent(err error, component string) error {
+	return With(err, Components(component))
+}
+
+// RegisterDomain assigns all packages whose path starts with the given prefix to a domain. If multiple prefixes
+// match a package, the longest one wins.
+//
+//	eris.RegisterDomain("github.com/example/cloud/internal/compaction", "storage")
+func RegisterDomain(prefix, domain string) {
+	domainsMu.Lock()
+	defer domainsMu.Unlock()
+	domains[strings.TrimSuffix(prefix, "/")] = domain
+}
+
+// UnregisterDomain removes a prefix registered via RegisterDomain.
+func UnregisterDomain(prefix string) {
+	domainsMu.Lock()
+	defer domainsMu.Unlock()
+	delete(domains, strings.TrimSuffix(prefix, "/"))
+}
+
+// Domain returns the domain of the outermost error in the chain that is tagged via WithDomain.
+//
+// If no error is tagged, the domain is inferred from the package path of the frame where the root error was
+// created: the domain registered for the package via RegisterDomain, or else the first element of the package
+// path relative to its module, e.g. "storage" for "<module>/storage/compactor", see StackFrame.Module. Returns an
+// empty string if the domain can't be inferred, e.g. for external errors or packages of dependencies.
+func Domain(err error) string {
+	type Domainer interface {
+		Domain() string
+	}
+	var domain string
+	walk(err, func(err error) bool {
+		if domainErr, ok := err.(Domainer); ok {
+			domain = domainErr.Domain()
+		}
+		return domain == ""
+	})
+	if domain != "" {
+		return domain
+	}
+	origin := originFrame(err)
+	if origin.Package == "" {
+		return ""
+	}
+	if domain, ok := registeredDomain(origin.Package); ok {
+		return domain
+	}
+	switch module := origin.Module(); {
+	case module == "":
+		return ""
+	case origin.Package == module:
+		return lastElement(module)
+	default:
+		first, _, _ := strings.Cut(strings.TrimPrefix(origin.Package, module+"/"), "/")
+		return first
+	}
+}
+
+// Component returns the component of the outermost error in the chain that is tagged via WithComponent.
+//
+// If no error is tagged, the component is the name of the package where the root error was created, e.g.
+// "compactor" for "<module>/storage/compactor". Returns an empty string for external errors.
+func Component(err error) string {
+	type Componenter interface {
+		Component() string
+	}
+	var component string
+	walk(err, func(err error) bool {
+		if componentErr, ok := err.(Componenter); ok {
+			component = componentErr.Component()
+		}
+		return component == ""
+	})
+	if component != "" {
+		return component
+	}
+	return lastElement(originFrame(err).Package)
+}
+
+// originFrame returns the frame where the first root error in the chain was created, or an empty frame if
+// there is none.
+func originFrame(err error) StackFrame {
+	var pc uintptr
+	walk(err, func(err error) bool {
+		if root, ok := err.(*rootError); ok && root.stack != nil && len(*root.stack) > 0 {
+			pc = (*root.stack)[0]
+			return false
+		}
+		return true
+	})
+	if pc == 0 {
+		return StackFrame{}
+	}
+	return frame(pc).get()
+}
+
+// packagePath returns the package path of a fully qualified function name, e.g. "example.com/a/b" for
+// "example.com/a/b.(*T).Method". The suffix of external test packages is removed.
+func packagePath(name string) string {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+	// the runtime escapes dots in the last element of package paths, e.g. gopkg.in/yaml%2ev3
+	name = strings.ReplaceAll(name, "%2e", ".")
+	return strings.TrimSuffix(name, "_test")
+}
+
+// registeredDomain returns the domain of the longest registered prefix matching the package path.
+func registeredDomain(pkg string) (string, bool) {
+	domainsMu.RLock()
+	defer domainsMu.RUnlock()
+	var domain, longest string
+	found := false
+	for prefix, d := range domains {
+		if hasPathPrefix(pkg, prefix) && (!found || len(prefix) > len(longest)) {
+			domain, longest, found = d, prefix, true
+		}
+	}
+	return domain, found
+}
+
+// lastElement returns the last element of a slash-separated path.
+func lastElement(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,84 @@
+package eris_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestDomain(t *testing.T) {
+	inferred := eris.Wrap(eris.New("compaction failed"), "wrap")
+	tests := map[string]struct {
+		err       error
+		domain    string
+		component string
+	}{
+		"inferred from root frame": {
+			err:       inferred,
+			domain:    "eris",
+			component: "eris",
+		},
+		"explicit": {
+			err:       eris.WithComponent(eris.WithDomain(eris.New("compaction failed"), "storage"), "compactor"),
+			domain:    "storage",
+			component: "compactor",
+		},
+		"outermost explicit wins": {
+			err:       eris.WithDomain(eris.Wrap(eris.WithDomain(eris.New("root"), "storage"), "wrap"), "streaming"),
+			domain:    "streaming",
+			component: "eris",
+		},
+		"external": {
+			err: errors.New("external"),
+		},
+		"joined": {
+			err:       eris.Join(errors.New("external"), eris.New("root")),
+			domain:    "eris",
+			component: "eris",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if domain := eris.Domain(tc.err); domain != tc.domain {
+				t.Errorf("%v: expected domain { %v } got { %v }", desc, tc.domain, domain)
+			}
+			if component := eris.Component(tc.err); component != tc.component {
+				t.Errorf("%v: expected component { %v } got { %v }", desc, tc.component, component)
+			}
+		})
+	}
+
+	prefixes := map[string]string{
+		"github.com/risingwavelabs":       "platform",
+		"github.com/risingwavelabs/eris/": "errors",
+		"github.com/risingwavelabs/eri":   "unrelated",
+	}
+	for prefix, domain := range prefixes {
+		prefix := prefix
+		eris.RegisterDomain(prefix, domain)
+		t.Cleanup(func() { eris.UnregisterDomain(prefix) })
+	}
+	if domain := eris.Domain(inferred); domain != "errors" {
+		t.Errorf("expected registered domain { %v } got { %v }", "errors", domain)
+	}
+	if domain := eris.Domain(eris.WithDomain(inferred, "storage")); domain != "storage" {
+		t.Errorf("expected explicit domain { %v } got { %v }", "storage", domain)
+	}
+}
+
+func TestDomainFields(t *testing.T) {
+	err := eris.WithDomain(eris.New("conflict").WithProperty("domain", "user value").WithComponent("compactor"), "storage")
+	if msg := err.Error(); msg != "code(unknown) KVs(map[domain:user value]) conflict" {
+		t.Errorf("expected domain and component not to be rendered got { %v }", msg)
+	}
+	if domain := eris.Domain(err); domain != "storage" {
+		t.Errorf("expected domain { %v } got { %v }", "storage", domain)
+	}
+	if component := eris.Component(err); component != "compactor" {
+		t.Errorf("expected component { %v } got { %v }", "compactor", component)
+	}
+	if value, _ := eris.GetProperty[string](err, "domain"); value != "user value" {
+		t.Errorf("expected property { %v } got { %v }", "user value", value)
+	}
+}
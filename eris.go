@@ -17,7 +17,16 @@ type statusError interface {
 	WithCodeGrpc(grpc.Code) statusError
 	WithCodeHttp(HTTPStatus) statusError
 	WithProperty(string, any) statusError
+	WithHint(string) statusError
+	WithDetail(string) statusError
+	WithDocURL(string) statusError
+	WithPublicMessage(string) statusError
+	WithSeverity(Severity) statusError
+	WithDomain(string) statusError
+	WithComponent(string) statusError
+	WithHTTPStatus(HTTPStatus) statusError
 	Code() Code
+	HasExplicitCode() bool
 	HasKVs() bool
 	KVs() map[string]any
 }
@@ -34,6 +43,22 @@ func GetCode(err error) Code {
 	return codeErr.Code()
 }
 
+// HasExplicitCode returns true if the error code was set explicitly, e.g. via WithCode, instead of being
+// the default assigned by New or Wrap. External errors implementing `Code() Code` always count as explicit.
+func HasExplicitCode(err error) bool {
+	type explicitCoder interface {
+		HasExplicitCode() bool
+	}
+	if codeErr, ok := err.(explicitCoder); ok {
+		return codeErr.HasExplicitCode()
+	}
+	type Coder interface {
+		Code() Code
+	}
+	_, ok := err.(Coder)
+	return ok
+}
+
 // GetKVs returns the error code. Returns nil if error doesn't support kvs.
 func GetKVs(err error) map[string]any {
 	type KVer interface {
@@ -53,7 +78,7 @@ func GetProperty[T any](err error, key string) (T, bool) {
 		var empty T
 		return empty, false
 	}
-	typed, ok := val.(T)
+	typed, ok := unwrapValue(val).(T)
 	if !ok {
 		var empty T
 		return empty, false
@@ -67,7 +92,7 @@ func GetPropertyP[T any](err error, key string) *T {
 	if !ok {
 		return nil
 	}
-	typed, ok := val.(T)
+	typed, ok := unwrapValue(val).(T)
 	if !ok {
 		return nil
 	}
@@ -77,39 +102,75 @@ func GetPropertyP[T any](err error, key string) *T {
 // New creates a new root error with a static message and an error code 'unknown'.
 func New(msg string) statusError {
 	stack := callers(3) // callers(3) skips this method, stack.callers, and runtime.Callers
-	return &rootError{
+	root := &rootError{
 		global: stack.isGlobal(),
 		msg:    msg,
 		stack:  stack,
 		code:   DEFAULT_ERROR_CODE_NEW,
 	}
+	notifyCreate(OpNew, root)
+	return root
 }
 
 // Errorf creates a new root error with a formatted message and an error code 'unknown'.
+//
+// Arguments wrapped with Sensitive are redacted when the error is printed.
 func Errorf(format string, args ...any) statusError {
 	stack := callers(3)
-	return &rootError{
+	root := &rootError{
 		global: stack.isGlobal(),
-		msg:    fmt.Sprintf(format, args...),
+		msg:    formatMsg(format, args, RedactMask),
+		format: format,
+		args:   args,
+		vars:   newMsgVariants(format, args),
 		stack:  stack,
 		code:   DEFAULT_ERROR_CODE_NEW,
 	}
+	notifyCreate(OpNew, root)
+	return root
 }
 
-type joinError interface {
-	Unwrap() []error
-}
-
-// Join returns an error that wraps the given errors.
+// Join returns an error that wraps the given errors. Nil errors are discarded and nil is returned if all
+// errors are nil. The joined errors are reachable via Is and As, like with `errors.Join`.
 func Join(errs ...error) error {
-	internal := errors.Join(errs...)
+	internal := newJoinError(errs)
 	if internal == nil {
 		return nil
 	}
-	return wrap(internal, "join error", DEFAULT_ERROR_CODE_NEW)
+	return wrap(internal, "join error", "", nil, DEFAULT_ERROR_CODE_NEW)
+}
+
+// joinError holds the errors joined by Join. Like rootError and wrapError, it is never matched by As itself.
+type joinError struct {
+	errs []error
+}
+
+// newJoinError returns a joinError of all non-nil errors, or nil if there are none.
+func newJoinError(errs []error) *joinError {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return &joinError{errs: nonNil}
+}
+
+// Error returns the messages of the joined errors separated by newlines, like `errors.Join`.
+func (e *joinError) Error() string {
+	return errors.Join(e.errs...).Error()
+}
+
+// Unwrap returns the joined errors.
+func (e *joinError) Unwrap() []error {
+	return e.errs
 }
 
 // Wrap adds additional context to all error types while maintaining the type of the original error. Adds a default error code 'internal'
+// or, if the chain has no explicit code, 'canceled' and 'deadline exceeded' for context errors.
 //
 // This method behaves differently for each error type. For root errors, the stack trace is reset to the current
 // callers which ensures traces are correct when using global/sentinel error values. Wrapped error types are simply
@@ -118,14 +179,15 @@ func Join(errs ...error) error {
 // interface, it flattens the error and creates a new root error from it before wrapping with the additional
 // context.
 func Wrap(err error, msg string) error {
-	return wrap(err, fmt.Sprint(msg), DEFAULT_ERROR_CODE_WRAP)
+	return wrap(err, fmt.Sprint(msg), "", nil, contextCode(err, DEFAULT_ERROR_CODE_WRAP))
 }
 
 // Wrapf adds additional context to all error types while maintaining the type of the original error. Adds a default error code 'internal'
 //
 // This is a convenience method for wrapping errors with formatted messages and is otherwise the same as Wrap.
+// Arguments wrapped with Sensitive are redacted when the error is printed.
 func Wrapf(err error, format string, args ...any) error {
-	return wrap(err, fmt.Sprintf(format, args...), DEFAULT_ERROR_CODE_WRAP)
+	return wrap(err, formatMsg(format, args, RedactMask), format, args, contextCode(err, DEFAULT_ERROR_CODE_WRAP))
 }
 
 // PassThrough adds additional context to all error types while maintaining the type of the original error.
@@ -142,10 +204,13 @@ func PassThroughf(err error, format string, args ...any) error {
 	if err == nil {
 		return nil
 	}
-	newErr := wrap(err, fmt.Sprintf(format, args...), DEFAULT_ERROR_CODE_WRAP)
-
-	code := GetCode(err)
-	if code != CodeUnknown {
+	// keep the code of the underlying error, but only mark it as explicit if it was explicit before
+	code, explicit := GetCode(err), HasExplicitCode(err)
+	if code == CodeUnknown {
+		code, explicit = contextCode(err, DEFAULT_ERROR_CODE_WRAP), false
+	}
+	newErr := wrap(err, formatMsg(format, args, RedactMask), format, args, code)
+	if explicit {
 		newErr = WithCode(newErr, code)
 	}
 	kvs := GetKVs(err)
@@ -155,7 +220,7 @@ func PassThroughf(err error, format string, args ...any) error {
 	return newErr
 }
 
-func wrap(err error, msg string, code Code) error {
+func wrap(err error, msg string, format string, args []any, code Code) error {
 	if err == nil {
 		return nil
 	}
@@ -172,33 +237,86 @@ func wrap(err error, msg string, code Code) error {
 			err = &rootError{
 				global: e.global,
 				msg:    e.msg,
+				format: e.format,
+				args:   e.args,
+				vars:   e.vars,
 				stack:  stack,
 				code:   e.code,
+				coded:  e.coded,
+				hints:  e.hints,
+				detail: e.detail,
+				docURL: e.docURL,
+				public: e.public,
+				id:     e.id,
+				sev:    e.sev,
+				domain: e.domain,
+				comp:   e.comp,
+				status: e.status,
 			}
 		} else {
 			// insert the frame into the stack
 			e.stack.insertPC(*stack)
 		}
 	case *wrapError:
-		// insert the frame into the stack
-		if root, ok := Cause(err).(*rootError); ok {
+		// insert the frame into the stack of the root error of this chain
+		if root, ok := chainRoot(e); ok {
 			root.stack.insertPC(*stack)
 		}
 	default:
 		// return a new root error that wraps the external error
-		return &rootError{
-			msg:   msg,
-			ext:   e,
-			stack: stack,
-			code:  code,
+		root := &rootError{
+			msg:    msg,
+			format: format,
+			args:   args,
+			vars:   newMsgVariants(format, args),
+			ext:    e,
+			stack:  stack,
+			code:   code,
 		}
+		notifyCreate(OpWrap, root)
+		return root
 	}
 
-	return &wrapError{
-		msg:   msg,
-		err:   err,
-		frame: frame,
-		code:  code,
+	wrapped := &wrapError{
+		msg:    msg,
+		format: format,
+		args:   args,
+		vars:   newMsgVariants(format, args),
+		err:    err,
+		frame:  frame,
+		code:   code,
+	}
+	notifyCreate(OpWrap, wrapped)
+	return wrapped
+}
+
+// wrapAt wraps err like Wrap, but with a stack captured earlier, e.g. where a goroutine was spawned, instead of
+// the current one. The effective code of err is kept as the default code of the new link.
+func wrapAt(err error, msg string, site *stack) error {
+	if len(*site) == 0 {
+		return err
+	}
+	code := EffectiveCode(err, OutermostExplicit)
+	switch err.(type) {
+	case *rootError, *wrapError:
+		f := frame((*site)[0])
+		wrapped := &wrapError{
+			msg:   msg,
+			err:   err,
+			frame: &f,
+			code:  code,
+		}
+		notifyCreate(OpWrap, wrapped)
+		return wrapped
+	default:
+		root := &rootError{
+			msg:   msg,
+			ext:   err,
+			stack: site,
+			code:  code,
+		}
+		notifyCreate(OpWrap, root)
+		return root
 	}
 }
 
@@ -214,9 +332,11 @@ func Unwrap(err error) error {
 	return u.Unwrap()
 }
 
-// Is reports whether any error in err's chain matches target.
+// Is reports whether any error in err's tree matches target.
 //
-// The chain consists of err itself followed by the sequence of errors obtained by repeatedly calling Unwrap.
+// The tree consists of err itself, followed by the errors obtained by repeatedly calling its `Unwrap() error` or
+// `Unwrap() []error` method. When err wraps multiple errors, Is examines err followed by a depth-first traversal
+// of its children, like the standard library.
 //
 // An error is considered to match a target if it is equal to that target or if it implements a method
 // Is(error) bool such that Is(target) returns true.
@@ -226,23 +346,23 @@ func Is(err, target error) bool {
 	}
 
 	isComparable := reflect.TypeOf(target).Comparable()
-	for {
+	return !walk(err, func(err error) bool {
 		if isComparable && err == target {
-			return true
+			return false
 		}
 		if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
-			return true
-		}
-		if err = Unwrap(err); err == nil {
 			return false
 		}
-	}
+		return true
+	})
 }
 
-// As finds the first error in err's chain that matches target. If there's a match, it sets target to that error
+// As finds the first error in err's tree that matches target. If there's a match, it sets target to that error
 // value and returns true. Otherwise, it returns false.
 //
-// The chain consists of err itself followed by the sequence of errors obtained by repeatedly calling Unwrap.
+// The tree consists of err itself, followed by the errors obtained by repeatedly calling its `Unwrap() error` or
+// `Unwrap() []error` method. When err wraps multiple errors, As examines err followed by a depth-first traversal
+// of its children, like the standard library.
 //
 // An error matches target if the error's concrete value is assignable to the value pointed to by target,
 // or if the error has a method As(any) bool such that As(target) returns true.
@@ -263,30 +383,41 @@ func As(err error, target any) bool {
 		return false
 	}
 
-	for {
+	return !walk(err, func(err error) bool {
 		errType := reflect.TypeOf(err)
-		if errType != reflect.TypeOf(&wrapError{}) && errType != reflect.TypeOf(&rootError{}) && reflect.TypeOf(err).AssignableTo(typ.Elem()) {
+		if errType != reflect.TypeOf(&wrapError{}) && errType != reflect.TypeOf(&rootError{}) && errType != reflect.TypeOf(&joinError{}) && reflect.TypeOf(err).AssignableTo(typ.Elem()) {
 			val.Elem().Set(reflect.ValueOf(err))
-			return true
+			return false
 		}
 		if x, ok := err.(interface{ As(any) bool }); ok && x.As(target) {
-			return true
-		}
-		if err = Unwrap(err); err == nil {
 			return false
 		}
-	}
+		return true
+	})
 }
 
 // Cause returns the root cause of the error, which is defined as the first error in the chain. The original
 // error is returned if it does not implement `Unwrap() error` and nil is returned if the error is nil.
+//
+// If an error in the chain wraps multiple errors via `Unwrap() []error`, Cause follows the first of them.
 func Cause(err error) error {
 	for {
-		uerr := Unwrap(err)
-		if uerr == nil {
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			uerr := x.Unwrap()
+			if uerr == nil {
+				return err
+			}
+			err = uerr
+		case interface{ Unwrap() []error }:
+			errs := x.Unwrap()
+			if len(errs) == 0 || errs[0] == nil {
+				return err
+			}
+			err = errs[0]
+		default:
 			return err
 		}
-		err = uerr
 	}
 }
 
@@ -316,11 +447,17 @@ func With(err error, fields ...Field) error {
 		for _, field := range fields {
 			root = root.WithField(field).(*rootError)
 		}
+		if len(fields) > 0 {
+			notifyCreate(OpWith, root)
+		}
 		return root
 	} else if wrap, ok := err.(*wrapError); ok {
 		for _, field := range fields {
 			wrap = wrap.WithField(field).(*wrapError)
 		}
+		if len(fields) > 0 {
+			notifyCreate(OpWith, wrap)
+		}
 		return wrap
 	} else {
 		return With(Wrap(err, "with property"), fields...)
@@ -347,6 +484,24 @@ const (
 	CodeType
 	// KVType the field type is a key-value.
 	KVType
+	// HintType the field type is a hint.
+	HintType
+	// DetailType the field type is a detail.
+	DetailType
+	// DocURLType the field type is a documentation link.
+	DocURLType
+	// PublicMessageType the field type is a public message.
+	PublicMessageType
+	// SeverityType the field type is a severity.
+	SeverityType
+	// DomainType the field type is a domain.
+	DomainType
+	// ComponentType the field type is a component.
+	ComponentType
+	// HTTPStatusType the field type is an HTTP status override.
+	HTTPStatusType
+	// KeyType the field type is a property of a typed key, see Key.
+	KeyType
 )
 
 // Field is the additional property an error could be attached.
@@ -374,25 +529,40 @@ func KVs(key string, value any) Field {
 }
 
 type rootError struct {
-	global bool   // flag indicating whether the error was declared globally
-	msg    string // root error message
-	ext    error  // error type for wrapping external errors
-	stack  *stack // root error stack trace
+	global bool         // flag indicating whether the error was declared globally
+	msg    string       // root error message
+	format string       // format of the root error message, if created via Errorf
+	args   []any        // arguments of the root error message, if created via Errorf
+	vars   *msgVariants // unmasked variants of the message, if an argument is sensitive
+	ext    error        // error type for wrapping external errors
+	stack  *stack       // root error stack trace
 	code   Code
+	coded  bool // flag indicating whether the code was set explicitly instead of defaulted
 	kvs    map[string]any
+	keys   []keyValue // properties set via typed keys, see Key
+	hints  []string   // actionable suggestions for the user
+	detail string     // additional information about the error
+	docURL string     // link to the documentation of the error
+	public string     // message that is safe to show to clients
+	id     string     // stable ID of the definition the error was created from, see Define
+	sev    Severity   // severity overriding the default of the code, zero if not set
+	domain string     // domain owning the error, see WithDomain
+	comp   string     // component that raised the error, see WithComponent
+	status HTTPStatus // HTTP status overriding the one derived from the code, zero if not set
 }
 
 // KVs returns the key-value pairs associated with the error.
 func (e *rootError) KVs() map[string]any {
-	if e.kvs == nil {
+	if e.kvs == nil && len(e.keys) == 0 {
 		return make(map[string]any)
 	}
-	return e.kvs
+	return mergeKeys(e.kvs, e.keys)
 }
 
 // WithCode sets the error code.
 func (e *rootError) WithCode(code Code) statusError {
-	e.code = code
+	e.setCode(code)
+	notifyCreate(OpWith, e)
 	return e
 }
 
@@ -404,8 +574,8 @@ func (e *rootError) WithCodeGrpc(code grpc.Code) statusError {
 	if code == grpc.OK {
 		return e
 	}
-	e.code, _ = fromGrpc(code)
-	return e
+	c, _ := fromGrpc(code)
+	return e.WithCode(c)
 }
 
 // WithCodeHttp sets the error code, based on an HTTP status code.
@@ -413,8 +583,15 @@ func (e *rootError) WithCodeHttp(code HTTPStatus) statusError {
 	if code == http.StatusOK {
 		return e
 	}
-	e.code, _ = fromHttp(code)
-	return e
+	c, _ := fromHttp(code)
+	return e.WithCode(c)
+}
+
+// setCode sets the error code explicitly. Unlike WithCode, it doesn't notify the hooks, so With can notify them
+// once for all fields.
+func (e *rootError) setCode(code Code) {
+	e.code = code
+	e.coded = true
 }
 
 // WithProperty adds a key-value pair to the error.
@@ -426,24 +603,143 @@ func (e *rootError) WithProperty(key string, value any) statusError {
 	return e
 }
 
-// WithField adds a key-value pair to the error.
+// WithHint adds an actionable suggestion for the user to the error.
+func (e *rootError) WithHint(hint string) statusError {
+	e.hints = append(e.hints, hint)
+	return e
+}
+
+// WithDetail sets additional information about the error, e.g. which value violated a constraint.
+func (e *rootError) WithDetail(detail string) statusError {
+	e.detail = detail
+	return e
+}
+
+// WithDocURL sets a link to the documentation of the error.
+func (e *rootError) WithDocURL(url string) statusError {
+	e.docURL = url
+	return e
+}
+
+// WithPublicMessage sets a message that is safe to show to clients, see PublicMessage.
+func (e *rootError) WithPublicMessage(msg string) statusError {
+	e.public = msg
+	return e
+}
+
+// WithSeverity sets the severity of the error, overriding the default derived from its code.
+func (e *rootError) WithSeverity(severity Severity) statusError {
+	e.sev = severity
+	return e
+}
+
+// WithDomain sets the domain that owns the error, e.g. "storage".
+func (e *rootError) WithDomain(domain string) statusError {
+	e.domain = domain
+	return e
+}
+
+// WithComponent sets the component that raised the error, e.g. "compactor".
+func (e *rootError) WithComponent(component string) statusError {
+	e.comp = component
+	return e
+}
+
+// WithHTTPStatus overrides the HTTP status that ToHTTP derives from the code of the error.
+func (e *rootError) WithHTTPStatus(status HTTPStatus) statusError {
+	e.status = status
+	return e
+}
+
+// WithField adds a field to the error.
 func (e *rootError) WithField(field Field) statusError {
-	if field.Type == CodeType {
-		return e.WithCode(field.Value.(Code))
-	} else if field.Type == KVType {
+	switch field.Type {
+	case CodeType:
+		e.setCode(field.Value.(Code))
+		return e
+	case KVType:
 		return e.WithProperty(field.Key, field.Value)
+	case HintType:
+		return e.WithHint(field.Value.(string))
+	case DetailType:
+		return e.WithDetail(field.Value.(string))
+	case DocURLType:
+		return e.WithDocURL(field.Value.(string))
+	case PublicMessageType:
+		return e.WithPublicMessage(field.Value.(string))
+	case SeverityType:
+		return e.WithSeverity(field.Value.(Severity))
+	case DomainType:
+		return e.WithDomain(field.Value.(string))
+	case ComponentType:
+		return e.WithComponent(field.Value.(string))
+	case HTTPStatusType:
+		return e.WithHTTPStatus(field.Value.(HTTPStatus))
+	case KeyType:
+		e.keys = setKeyValue(e.keys, field.Value.(keyValue))
+		return e
 	}
 	return e
 }
 
+// Hints returns the hints of the error.
+func (e *rootError) Hints() []string {
+	return e.hints
+}
+
+// Detail returns the detail of the error.
+func (e *rootError) Detail() string {
+	return e.detail
+}
+
+// DocURL returns the documentation link of the error.
+func (e *rootError) DocURL() string {
+	return e.docURL
+}
+
+// PublicMessage returns the public message of the error, or an empty string if it has none.
+func (e *rootError) PublicMessage() string {
+	return e.public
+}
+
+// ID returns the ID of the definition the error was created from, or an empty string.
+func (e *rootError) ID() string {
+	return e.id
+}
+
+// Severity returns the severity set on the error, or zero if it has none.
+func (e *rootError) Severity() Severity {
+	return e.sev
+}
+
+// Domain returns the domain set on the error, or an empty string if it has none.
+func (e *rootError) Domain() string {
+	return e.domain
+}
+
+// Component returns the component set on the error, or an empty string if it has none.
+func (e *rootError) Component() string {
+	return e.comp
+}
+
+// HTTPStatus returns the HTTP status override of the error, or zero if it has none.
+func (e *rootError) HTTPStatus() HTTPStatus {
+	return e.status
+}
+
 // Code returns the error code.
 func (e *rootError) Code() Code {
 	return e.code
 }
 
+// HasExplicitCode returns true if the code was set explicitly instead of being a default.
+func (e *rootError) HasExplicitCode() bool {
+	return e.coded
+}
+
 // HasKVs returns true if the error has key-value pairs.
 func (e *rootError) HasKVs() bool {
-	return e.kvs != nil && len(e.kvs) > 0
+	return len(e.kvs) > 0 || len(e.keys) > 0
 }
 
 // GetKVs returns the key-value pairs associated with the error.
@@ -460,37 +756,24 @@ func (e *rootError) Format(s fmt.State, verb rune) {
 	printError(e, s, verb)
 }
 
-// Is returns true if both errors have the same message and code.
-// In case of a joined error, returns true if at least one of the joined errors is equal to target.
-// Ignores additional KV pairs.
+// Is returns true if both errors have the same message, code and KV pairs. If the target is a definition,
+// it returns true if the error was created from it, regardless of message and KV pairs.
+// Joined errors are matched by the package level Is, which traverses them.
 func (e *rootError) Is(target error) bool {
-	if joinErr, ok := e.ext.(joinError); ok {
-		for _, err := range joinErr.Unwrap() {
-			if Is(err, target) {
-				return true
-			}
-		}
-		return false
+	if def, ok := target.(*Definition); ok {
+		return e.id != "" && e.id == def.id
 	}
 	if err, ok := target.(*rootError); ok {
-		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs)
+		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs) && reflect.DeepEqual(e.keys, err.keys)
 	}
 	if err, ok := target.(*wrapError); ok {
-		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs)
+		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs) && reflect.DeepEqual(e.keys, err.keys)
 	}
 	return e.msg == target.Error() && e.code == DEFAULT_UNKNOWN_CODE
 }
 
 // As returns true if the error message in the target error is equivalent to the error message in the root error.
 func (e *rootError) As(target any) bool {
-	if joinErr, ok := e.ext.(joinError); ok {
-		for _, err := range joinErr.Unwrap() {
-			if As(err, target) {
-				return true
-			}
-		}
-		return false
-	}
 	t := reflect.Indirect(reflect.ValueOf(target)).Interface()
 	if err, ok := t.(*rootError); ok {
 		if e.msg == err.msg {
@@ -513,24 +796,39 @@ func (e *rootError) StackFrames() []uintptr {
 }
 
 type wrapError struct {
-	msg   string // wrap error message
-	err   error  // error type representing the next error in the chain
-	frame *frame // wrap error stack frame
-	code  Code
-	kvs   map[string]any
+	msg    string       // wrap error message
+	format string       // format of the wrap error message, if created via Wrapf
+	args   []any        // arguments of the wrap error message, if created via Wrapf
+	vars   *msgVariants // unmasked variants of the message, if an argument is sensitive
+	err    error        // error type representing the next error in the chain
+	frame  *frame       // wrap error stack frame
+	code   Code
+	coded  bool // flag indicating whether the code was set explicitly instead of defaulted
+	kvs    map[string]any
+	keys   []keyValue // properties set via typed keys, see Key
+	hints  []string   // actionable suggestions for the user
+	detail string     // additional information about the error
+	docURL string     // link to the documentation of the error
+	public string     // message that is safe to show to clients
+	id     string     // stable ID of the definition the error was created from, see Define
+	sev    Severity   // severity overriding the default of the code, zero if not set
+	domain string     // domain owning the error, see WithDomain
+	comp   string     // component that raised the error, see WithComponent
+	status HTTPStatus // HTTP status overriding the one derived from the code, zero if not set
 }
 
 // KVs returns the key-value pairs associated with the error.
 func (e *wrapError) KVs() map[string]any {
-	if e.kvs == nil {
+	if e.kvs == nil && len(e.keys) == 0 {
 		return make(map[string]any)
 	}
-	return e.kvs
+	return mergeKeys(e.kvs, e.keys)
 }
 
 // WithCode sets the error code.
 func (e *wrapError) WithCode(code Code) statusError {
-	e.code = code
+	e.setCode(code)
+	notifyCreate(OpWith, e)
 	return e
 }
 
@@ -539,8 +837,8 @@ func (e *wrapError) WithCodeGrpc(code grpc.Code) statusError {
 	if code == grpc.OK {
 		return e
 	}
-	e.code, _ = fromGrpc(code)
-	return e
+	c, _ := fromGrpc(code)
+	return e.WithCode(c)
 }
 
 // WithCodeHttp sets the error code, based on an HTTP status code.
@@ -548,8 +846,15 @@ func (e *wrapError) WithCodeHttp(code HTTPStatus) statusError {
 	if code == http.StatusOK {
 		return e
 	}
-	e.code, _ = fromHttp(code)
-	return e
+	c, _ := fromHttp(code)
+	return e.WithCode(c)
+}
+
+// setCode sets the error code explicitly. Unlike WithCode, it doesn't notify the hooks, so With can notify them
+// once for all fields.
+func (e *wrapError) setCode(code Code) {
+	e.code = code
+	e.coded = true
 }
 
 // WithProperty adds a key-value pair to the error.
@@ -561,24 +866,143 @@ func (e *wrapError) WithProperty(key string, value any) statusError {
 	return e
 }
 
-// WithField adds a key-value pair to the error.
+// WithHint adds an actionable suggestion for the user to the error.
+func (e *wrapError) WithHint(hint string) statusError {
+	e.hints = append(e.hints, hint)
+	return e
+}
+
+// WithDetail sets additional information about the error, e.g. which value violated a constraint.
+func (e *wrapError) WithDetail(detail string) statusError {
+	e.detail = detail
+	return e
+}
+
+// WithDocURL sets a link to the documentation of the error.
+func (e *wrapError) WithDocURL(url string) statusError {
+	e.docURL = url
+	return e
+}
+
+// WithPublicMessage sets a message that is safe to show to clients, see PublicMessage.
+func (e *wrapError) WithPublicMessage(msg string) statusError {
+	e.public = msg
+	return e
+}
+
+// WithSeverity sets the severity of the error, overriding the default derived from its code.
+func (e *wrapError) WithSeverity(severity Severity) statusError {
+	e.sev = severity
+	return e
+}
+
+// WithDomain sets the domain that owns the error, e.g. "storage".
+func (e *wrapError) WithDomain(domain string) statusError {
+	e.domain = domain
+	return e
+}
+
+// WithComponent sets the component that raised the error, e.g. "compactor".
+func (e *wrapError) WithComponent(component string) statusError {
+	e.comp = component
+	return e
+}
+
+// WithHTTPStatus overrides the HTTP status that ToHTTP derives from the code of the error.
+func (e *wrapError) WithHTTPStatus(status HTTPStatus) statusError {
+	e.status = status
+	return e
+}
+
+// WithField adds a field to the error.
 func (e *wrapError) WithField(field Field) statusError {
-	if field.Type == CodeType {
-		return e.WithCode(field.Value.(Code))
-	} else if field.Type == KVType {
+	switch field.Type {
+	case CodeType:
+		e.setCode(field.Value.(Code))
+		return e
+	case KVType:
 		return e.WithProperty(field.Key, field.Value)
+	case HintType:
+		return e.WithHint(field.Value.(string))
+	case DetailType:
+		return e.WithDetail(field.Value.(string))
+	case DocURLType:
+		return e.WithDocURL(field.Value.(string))
+	case PublicMessageType:
+		return e.WithPublicMessage(field.Value.(string))
+	case SeverityType:
+		return e.WithSeverity(field.Value.(Severity))
+	case DomainType:
+		return e.WithDomain(field.Value.(string))
+	case ComponentType:
+		return e.WithComponent(field.Value.(string))
+	case HTTPStatusType:
+		return e.WithHTTPStatus(field.Value.(HTTPStatus))
+	case KeyType:
+		e.keys = setKeyValue(e.keys, field.Value.(keyValue))
+		return e
 	}
 	return e
 }
 
+// Hints returns the hints of the error.
+func (e *wrapError) Hints() []string {
+	return e.hints
+}
+
+// Detail returns the detail of the error.
+func (e *wrapError) Detail() string {
+	return e.detail
+}
+
+// DocURL returns the documentation link of the error.
+func (e *wrapError) DocURL() string {
+	return e.docURL
+}
+
+// PublicMessage returns the public message of the error, or an empty string if it has none.
+func (e *wrapError) PublicMessage() string {
+	return e.public
+}
+
+// ID returns the ID of the definition the error was created from, or an empty string.
+func (e *wrapError) ID() string {
+	return e.id
+}
+
+// Severity returns the severity set on the error, or zero if it has none.
+func (e *wrapError) Severity() Severity {
+	return e.sev
+}
+
+// Domain returns the domain set on the error, or an empty string if it has none.
+func (e *wrapError) Domain() string {
+	return e.domain
+}
+
+// Component returns the component set on the error, or an empty string if it has none.
+func (e *wrapError) Component() string {
+	return e.comp
+}
+
+// HTTPStatus returns the HTTP status override of the error, or zero if it has none.
+func (e *wrapError) HTTPStatus() HTTPStatus {
+	return e.status
+}
+
 // Code returns the error code.
 func (e *wrapError) Code() Code {
 	return e.code
 }
 
+// HasExplicitCode returns true if the code was set explicitly instead of being a default.
+func (e *wrapError) HasExplicitCode() bool {
+	return e.coded
+}
+
 // HasKVs returns true if the error has key-value pairs.
 func (e *wrapError) HasKVs() bool {
-	return e.kvs != nil && len(e.kvs) > 0
+	return len(e.kvs) > 0 || len(e.keys) > 0
 }
 
 // Error returns the error message.
@@ -591,13 +1015,17 @@ func (e *wrapError) Format(s fmt.State, verb rune) {
 	printError(e, s, verb)
 }
 
-// Is returns true if error messages in both errors are equivalent.
+// Is returns true if error messages in both errors are equivalent. If the target is a definition, it returns
+// true if the error was created from it.
 func (e *wrapError) Is(target error) bool {
+	if def, ok := target.(*Definition); ok {
+		return e.id != "" && e.id == def.id
+	}
 	if err, ok := target.(*rootError); ok {
-		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs)
+		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs) && reflect.DeepEqual(e.keys, err.keys)
 	}
 	if err, ok := target.(*wrapError); ok {
-		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs)
+		return e.msg == err.msg && e.code == err.code && reflect.DeepEqual(e.kvs, err.kvs) && reflect.DeepEqual(e.keys, err.keys)
 	}
 	return e.msg == target.Error()
 }
@@ -632,6 +1060,13 @@ func printError(err error, s fmt.State, verb rune) {
 			withTrace = true
 		}
 	}
-	str := ToString(err, withTrace)
+	// hints are only printed with trace, so the message of Error() stays on a single line
+	str := ToCustomString(err, NewDefaultStringFormat(FormatOptions{
+		WithTrace:     withTrace,
+		WithExternal:  true,
+		WithHints:     withTrace,
+		RelativePaths: deterministicTrace.Load(),
+		MaskLines:     deterministicTrace.Load(),
+	}))
 	_, _ = io.WriteString(s, str)
 }
@@ -976,10 +976,12 @@ func getFrames(pc []uintptr) []eris.StackFrame {
 		frame, more := frames.Next()
 		i := strings.LastIndex(frame.Function, "/")
 		name := frame.Function[i+1:]
+		pkg := frame.Function[:i+1+strings.Index(name, ".")]
 		stackFrames = append(stackFrames, eris.StackFrame{
-			Name: name,
-			File: frame.File,
-			Line: frame.Line,
+			Name:    name,
+			File:    frame.File,
+			Line:    frame.Line,
+			Package: strings.TrimSuffix(pkg, "_test"),
 		})
 		if !more {
 			break
@@ -1093,3 +1095,43 @@ func TestJoinError(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiErrorTree(t *testing.T) {
+	extErr := errors.New("external error")
+	rootErr := eris.New("root error").WithCode(eris.CodeNotFound)
+	custom := withMessage{msg: "custom error"}
+
+	tests := map[string]struct {
+		input error
+		cause error
+	}{
+		"errors.Join inside external error": {
+			input: eris.Wrap(fmt.Errorf("context: %w", errors.Join(extErr, rootErr, custom)), "wrap"),
+			cause: extErr,
+		},
+		"fmt.Errorf with multiple %w": {
+			input: eris.Wrap(fmt.Errorf("context: %w, %w, %w", extErr, rootErr, custom), "wrap"),
+			cause: extErr,
+		},
+		"nested joins": {
+			input: eris.Join(eris.Wrap(errors.Join(extErr, rootErr), "wrap"), custom),
+			cause: extErr,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			for _, target := range []error{extErr, rootErr, custom} {
+				if !eris.Is(tc.input, target) {
+					t.Errorf("%v: expected eris.Is('%v', '%v') to return true but got false", desc, tc.input, target)
+				}
+			}
+			var target withMessage
+			if !eris.As(tc.input, &target) || target != custom {
+				t.Errorf("%v: expected eris.As to find { %v } got { %v }", desc, custom, target)
+			}
+			if cause := eris.Cause(tc.input); cause != tc.cause {
+				t.Errorf("%v: expected cause { %v } got { %v }", desc, tc.cause, cause)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package eristest provides test helpers for errors created with eris.
+//
+// The assertions report failures via t.Errorf and return whether they passed, so a test can stop early if a
+// failed assertion makes the remaining checks meaningless:
+//
+//	if !eristest.AssertCode(t, err, eris.CodeNotFound) {
+//		t.FailNow()
+//	}
+package eristest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+// AssertCode asserts that the effective code of err is code, i.e. the outermost explicitly set code of the chain
+// or the code of err itself if none was set explicitly. See eris.EffectiveCode.
+func AssertCode(t testing.TB, err error, code eris.Code) bool {
+	t.Helper()
+	if err == nil {
+		t.Errorf("expected error with code { %v } got { nil }", code)
+		return false
+	}
+	if got := eris.EffectiveCode(err, eris.OutermostExplicit); got != code {
+		t.Errorf("expected code { %v } got { %v }", code, got)
+		return false
+	}
+	return true
+}
+
+// AssertProperty asserts that the outermost error of the chain setting key has the value want.
+func AssertProperty(t testing.TB, err error, key string, want any) bool {
+	t.Helper()
+	got, ok := eris.FindProperty[any](err, key)
+	if !ok {
+		t.Errorf("expected property %q { %v } got none", key, want)
+		return false
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected property %q { %v } got { %v }", key, want, got)
+		return false
+	}
+	return true
+}
+
+// AssertChain asserts that the errors of the chain have the given messages, outermost first. Each message
+// belongs to a single error only, e.g. the chain of
+//
+//	eris.Wrap(eris.New("root"), "wrap")
+//
+// is "wrap", "root". Joined errors are traversed in depth-first order. The joining errors themselves, i.e. errors
+// implementing `Unwrap() []error`, are skipped, and external errors contribute their full message.
+func AssertChain(t testing.TB, err error, msgs ...string) bool {
+	t.Helper()
+	got := Chain(err)
+	if !reflect.DeepEqual(got, msgs) && (len(got) > 0 || len(msgs) > 0) {
+		t.Errorf("expected chain { %q } got { %q }", msgs, got)
+		return false
+	}
+	return true
+}
+
+// AssertIs asserts that eris.Is(err, target) is true.
+func AssertIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if !eris.Is(err, target) {
+		t.Errorf("expected { %v } to match { %v }", err, target)
+		return false
+	}
+	return true
+}
+
+// Chain returns the messages of the errors in the chain, outermost first, as compared by AssertChain.
+func Chain(err error) []string {
+	var msgs []string
+	eris.Find(err, func(view eris.ErrView) bool {
+		if _, ok := view.Err.(interface{ Unwrap() []error }); !ok {
+			msgs = append(msgs, view.Msg)
+		}
+		return false
+	})
+	return msgs
+}
@@ -0,0 +1,116 @@
+package eristest_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+	"github.com/risingwavelabs/eris/eristest"
+)
+
+// recorder records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	err := eris.WithProperty(eris.Wrap(eris.New("root").WithCode(eris.CodeNotFound).WithProperty("table", "users"), "wrap"), "retries", 3)
+	tests := map[string]struct {
+		assert func(t testing.TB) bool
+		pass   bool
+	}{
+		"code": {
+			assert: func(t testing.TB) bool { return eristest.AssertCode(t, err, eris.CodeNotFound) },
+			pass:   true,
+		},
+		"wrong code": {
+			assert: func(t testing.TB) bool { return eristest.AssertCode(t, err, eris.CodeInternal) },
+		},
+		"nil code": {
+			assert: func(t testing.TB) bool { return eristest.AssertCode(t, nil, eris.CodeInternal) },
+		},
+		"property": {
+			assert: func(t testing.TB) bool { return eristest.AssertProperty(t, err, "table", "users") },
+			pass:   true,
+		},
+		"outer property": {
+			assert: func(t testing.TB) bool { return eristest.AssertProperty(t, err, "retries", 3) },
+			pass:   true,
+		},
+		"wrong property": {
+			assert: func(t testing.TB) bool { return eristest.AssertProperty(t, err, "table", "orders") },
+		},
+		"missing property": {
+			assert: func(t testing.TB) bool { return eristest.AssertProperty(t, err, "column", "id") },
+		},
+		"chain": {
+			assert: func(t testing.TB) bool { return eristest.AssertChain(t, err, "wrap", "root") },
+			pass:   true,
+		},
+		"wrong chain": {
+			assert: func(t testing.TB) bool { return eristest.AssertChain(t, err, "root") },
+		},
+		"empty chain": {
+			assert: func(t testing.TB) bool { return eristest.AssertChain(t, nil) },
+			pass:   true,
+		},
+		"is": {
+			assert: func(t testing.TB) bool { return eristest.AssertIs(t, eris.Wrap(io.EOF, "read"), io.EOF) },
+			pass:   true,
+		},
+		"is definition": {
+			assert: func(t testing.TB) bool {
+				notFound := eris.Define("USER_NOT_FOUND", eris.CodeNotFound, "user %v not found")
+				return eristest.AssertIs(t, eris.Wrap(notFound.New(42), "lookup"), notFound)
+			},
+			pass: true,
+		},
+		"is not": {
+			assert: func(t testing.TB) bool { return eristest.AssertIs(t, err, io.EOF) },
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			r := &recorder{TB: t}
+			pass := tc.assert(r)
+			if pass != tc.pass || (len(r.failures) == 0) != tc.pass {
+				t.Errorf("%v: expected pass { %v } got { %v } with failures %q", desc, tc.pass, pass, r.failures)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		msgs []string
+	}{
+		"nil": {},
+		"root": {
+			err:  eris.New("root"),
+			msgs: []string{"root"},
+		},
+		"external": {
+			err:  eris.Wrap(eris.Wrap(io.EOF, "read"), "load"),
+			msgs: []string{"load", "read", "EOF"},
+		},
+		"joined": {
+			err:  eris.Wrap(eris.Join(eris.New("first"), errors.New("second")), "batch"),
+			msgs: []string{"batch", "join error", "first", "second"},
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			eristest.AssertChain(t, tc.err, tc.msgs...)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package eristest
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+var update = flag.Bool("update-golden", false, "update the golden files of eristest instead of comparing them")
+
+// frameLocation matches the file and line of a formatted stack frame, e.g. "/src/pkg/file.go:42".
+var frameLocation = regexp.MustCompile(`[^\s:"]*[/\\]([^\s:"/\\]+\.(?:go|s)):\d+`)
+
+// Normalize masks the locations of stack frames in formatted errors, so the output doesn't depend on the
+// checkout directory or on unrelated edits that shift line numbers. Paths are reduced to the file name and line
+// numbers are replaced with "*", e.g. "eris_test.TestX:/src/eris/eris_test.go:42" becomes
+// "eris_test.TestX:eris_test.go:*". It is meant for output that wasn't rendered with the FormatOptions
+// RelativePaths and MaskLines, e.g. of fmt.Sprintf("%+v", err).
+func Normalize(s string) string {
+	return frameLocation.ReplaceAllString(s, "$1:*")
+}
+
+// AssertString compares the output of eris.ToString(err, withTrace) with the golden file
+// "testdata/<test name>.golden". Stack frames are rendered with module-relative paths and masked line numbers.
+// Run the tests with -update-golden to write the golden files instead.
+func AssertString(t testing.TB, err error, withTrace bool) bool {
+	t.Helper()
+	str := eris.ToCustomString(err, eris.NewDefaultStringFormat(goldenOptions(withTrace)))
+	return AssertGolden(t, goldenPath(t, ".golden"), Normalize(str))
+}
+
+// AssertJSON compares the indented output of eris.ToJSON(err, withTrace) with the golden file
+// "testdata/<test name>.json". Stack frames are rendered with module-relative paths and masked line numbers.
+// Run the tests with -update-golden to write the golden files instead.
+func AssertJSON(t testing.TB, err error, withTrace bool) bool {
+	t.Helper()
+	data, jsonErr := json.MarshalIndent(eris.ToCustomJSON(err, eris.NewDefaultJSONFormat(goldenOptions(withTrace))), "", "  ")
+	if jsonErr != nil {
+		t.Errorf("failed to marshal error: %v", jsonErr)
+		return false
+	}
+	return AssertGolden(t, goldenPath(t, ".json"), Normalize(string(data))+"\n")
+}
+
+// AssertGolden compares got with the content of the golden file at path. Run the tests with -update-golden to
+// write got to the file instead.
+func AssertGolden(t testing.TB, path, got string) bool {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("failed to create golden file directory: %v", err)
+			return false
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Errorf("failed to write golden file: %v", err)
+			return false
+		}
+		return true
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("failed to read golden file (run with -update-golden to create it): %v", err)
+		return false
+	}
+	if got != string(want) {
+		t.Errorf("%v: expected {\n%v\n} got {\n%v\n}", path, string(want), got)
+		return false
+	}
+	return true
+}
+
+// goldenOptions returns the options of ToString and ToJSON with a deterministic trace, see
+// eris.SetDeterministicTrace. They must be kept in sync with the options of ToString and ToJSON.
+func goldenOptions(withTrace bool) eris.FormatOptions {
+	return eris.FormatOptions{
+		WithTrace:     withTrace,
+		WithExternal:  true,
+		WithHints:     true,
+		RelativePaths: true,
+		MaskLines:     true,
+	}
+}
+
+// goldenPath returns the path of the golden file of the running test with the given extension.
+func goldenPath(t testing.TB, ext string) string {
+	name := strings.NewReplacer("/", "__", " ", "_").Replace(t.Name())
+	return filepath.Join("testdata", name+ext)
+}
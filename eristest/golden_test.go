@@ -0,0 +1,70 @@
+package eristest_test
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+	"github.com/risingwavelabs/eris/eristest"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		output string
+	}{
+		"string": {
+			input:  "code(internal) wrap\n\teris_test.TestX:/src/eris/eris_test.go:42\n\truntime.goexit:/usr/local/go/src/runtime/asm_amd64.s:1700",
+			output: "code(internal) wrap\n\teris_test.TestX:eris_test.go:*\n\truntime.goexit:asm_amd64.s:*",
+		},
+		"json": {
+			input:  `{"stack":["eris_test.TestX:/src/eris/eris_test.go:42"]}`,
+			output: `{"stack":["eris_test.TestX:eris_test.go:*"]}`,
+		},
+		"without trace": {
+			input:  "code(not found) file.go:42 not found",
+			output: "code(not found) file.go:42 not found",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if got := eristest.Normalize(tc.input); got != tc.output {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.output, got)
+			}
+		})
+	}
+}
+
+func TestGolden(t *testing.T) {
+	err := eris.Wrap(eris.WithProperty(eris.WithCode(eris.Wrap(io.ErrUnexpectedEOF, "read failed"), eris.CodeDataLoss), "file", "data.json"), "load failed")
+	eristest.AssertString(t, err, false)
+	eristest.AssertJSON(t, err, false)
+}
+
+func TestGoldenMismatch(t *testing.T) {
+	r := &recorder{TB: t}
+	if eristest.AssertGolden(r, filepath.Join("testdata", "TestGolden.golden"), "something else") {
+		t.Errorf("expected mismatch")
+	}
+	if eristest.AssertGolden(r, filepath.Join("testdata", "missing.golden"), "") {
+		t.Errorf("expected missing golden file")
+	}
+	if len(r.failures) != 2 {
+		t.Errorf("expected { 2 } failures got { %v }", len(r.failures))
+	}
+}
+
+func TestGoldenTrace(t *testing.T) {
+	err := eris.Wrap(eris.New("not found").WithCode(eris.CodeNotFound), "lookup failed")
+	eristest.AssertString(t, err, true)
+	eristest.AssertJSON(t, err, true)
+
+	// the golden files hold the output of ToString and ToJSON with a deterministic trace
+	eris.SetDeterministicTrace(true)
+	defer eris.SetDeterministicTrace(false)
+	eristest.AssertGolden(t, filepath.Join("testdata", "TestGoldenTrace.golden"), eris.ToString(err, true))
+	data, _ := json.MarshalIndent(eris.ToJSON(err, true), "", "  ")
+	eristest.AssertGolden(t, filepath.Join("testdata", "TestGoldenTrace.json"), string(data)+"\n")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/risingwavelabs/eris"
@@ -322,3 +323,32 @@ func TestMainFunc(t *testing.T) {
 	// fmt.Println(string(b))
 	// panic("tmp")
 }
+
+// Demonstrates stack traces that don't depend on the machine, e.g. for examples and golden files.
+func ExampleSetDeterministicTrace() {
+	eris.SetDeterministicTrace(true)
+	defer eris.SetDeterministicTrace(false)
+
+	// example func that returns a wrapped error
+	readFile := func(fname string) error {
+		return eris.Wrapf(io.ErrUnexpectedEOF, "failed to read %v", fname)
+	}
+
+	err := eris.Wrap(readFile("example.json"), "failed to load config")
+	for _, line := range strings.Split(fmt.Sprintf("%+v", err), "\n") {
+		// cut the trace at the example's own frames, the frames of the test runner vary between Go releases
+		if strings.HasPrefix(line, "\tmain.") || strings.HasPrefix(line, "\ttesting.") {
+			continue
+		}
+		fmt.Println(line)
+	}
+
+	// Output:
+	// code(internal) failed to load config
+	// 	eris_test.ExampleSetDeterministicTrace:examples_test.go:*
+	// code(internal) failed to read example.json
+	// 	eris_test.ExampleSetDeterministicTrace:examples_test.go:*
+	// 	eris_test.ExampleSetDeterministicTrace:examples_test.go:*
+	// 	eris_test.ExampleSetDeterministicTrace.func1:examples_test.go:*
+	// unexpected EOF
+}
@@ -7,10 +7,17 @@ import (
 
 // FormatOptions defines output options like omitting stack traces and inverting the error or stack order.
 type FormatOptions struct {
-	InvertOutput bool // Flag that inverts the error output (wrap errors shown first).
-	WithTrace    bool // Flag that enables stack trace output.
-	InvertTrace  bool // Flag that inverts the stack trace output (top of call stack shown first).
-	WithExternal bool // Flag that enables external error output.
+	InvertOutput bool       // Flag that inverts the error output (wrap errors shown first).
+	WithTrace    bool       // Flag that enables stack trace output.
+	InvertTrace  bool       // Flag that inverts the stack trace output (top of call stack shown first).
+	WithExternal bool       // Flag that enables external error output.
+	WithHints    bool       // Flag that enables detail, hint and documentation link output in strings.
+	WithSeverity bool       // Flag that renders the effective severity once, before the errors, see GetSeverity.
+	Redact       RedactMode // Controls how sensitive values are rendered (masked by default).
+	// Flag that renders file paths of stack frames relative to their module, see StackFrame.RelativePath. Files of
+	// dependencies and the standard library are prefixed with their package path, e.g. "runtime/proc.go".
+	RelativePaths bool
+	MaskLines     bool // Flag that replaces line numbers of stack frames with "*".
 	// todo: maybe allow users to hide wrap frames if desired
 }
 
@@ -62,10 +69,20 @@ func NewDefaultStringFormat(options FormatOptions) StringFormat {
 // Without trace:
 //
 //	code(internal) even more context: code(data loss) KVs(map[bar:42 foo:true]) additional context: external error
+//
+// Details, hints and documentation links of the chain are appended as indented lines:
+//
+//	code(invalid argument) column "nme" does not exist
+//		DETAIL: table "users" has columns "id" and "name"
+//		HINT: perhaps you meant to reference the column "name"
+//		DOC: https://docs.example.com/errors/undefined-column
 func ToString(err error, withTrace bool) string {
 	return ToCustomString(err, NewDefaultStringFormat(FormatOptions{
-		WithTrace:    withTrace,
-		WithExternal: true,
+		WithTrace:     withTrace,
+		WithExternal:  true,
+		WithHints:     true,
+		RelativePaths: deterministicTrace.Load(),
+		MaskLines:     deterministicTrace.Load(),
 	}))
 }
 
@@ -99,12 +116,19 @@ func ToString(err error, withTrace bool) string {
 //	code(internal) even more context: code(data loss) KVs(map[bar:42 foo:true]) additional context: external error
 func ToCustomString(err error, format StringFormat) string {
 	upErr := Unpack(err)
+	return upErr.formatStr(format)
+}
 
+// String formatter for unpacked errors.
+func (upErr *UnpackedError) formatStr(format StringFormat) string {
 	var str string
+	if format.Options.WithSeverity && upErr.sev != 0 {
+		str = fmt.Sprintf("severity(%s) ", upErr.sev)
+	}
 	if format.Options.InvertOutput {
 		errSep := false
 		if format.Options.WithExternal && upErr.ErrExternal != nil {
-			externalStr := formatExternalStr(upErr.ErrExternal, format.Options.WithTrace)
+			externalStr := upErr.formatExternalStr(format)
 			str += externalStr
 			if strings.Contains(externalStr, "\n") {
 				str += "\n"
@@ -128,7 +152,7 @@ func ToCustomString(err error, format StringFormat) string {
 		}
 		str += upErr.ErrRoot.formatStr(format)
 		if format.Options.WithExternal && upErr.ErrExternal != nil {
-			externalStr := formatExternalStr(upErr.ErrExternal, format.Options.WithTrace)
+			externalStr := upErr.formatExternalStr(format)
 			if strings.Contains(externalStr, "\n") {
 				str += "\n"
 			} else if (format.Options.WithTrace && len(upErr.ErrRoot.Stack) > 0) || upErr.ErrRoot.Msg != "" {
@@ -138,6 +162,40 @@ func ToCustomString(err error, format StringFormat) string {
 		}
 	}
 
+	if format.Options.WithHints {
+		str += upErr.formatHintsStr()
+	}
+	return str
+}
+
+// String formatter for the details, hints and documentation links of the chain, outermost first.
+func (upErr *UnpackedError) formatHintsStr() string {
+	var detail, docURL string
+	var hints []string
+	collect := func(linkDetail string, linkHints []string, linkDocURL string) {
+		if detail == "" {
+			detail = linkDetail
+		}
+		if docURL == "" {
+			docURL = linkDocURL
+		}
+		hints = append(hints, linkHints...)
+	}
+	for i := len(upErr.ErrChain) - 1; i >= 0; i-- {
+		collect(upErr.ErrChain[i].detail, upErr.ErrChain[i].hints, upErr.ErrChain[i].docURL)
+	}
+	collect(upErr.ErrRoot.detail, upErr.ErrRoot.hints, upErr.ErrRoot.docURL)
+
+	var str string
+	if detail != "" {
+		str += "\n\tDETAIL: " + detail
+	}
+	for _, hint := range hints {
+		str += "\n\tHINT: " + hint
+	}
+	if docURL != "" {
+		str += "\n\tDOC: " + docURL
+	}
 	return str
 }
 
@@ -208,10 +266,15 @@ func NewDefaultJSONFormat(options FormatOptions) JSONFormat {
 //	        }
 //	    ]
 //	}
+//
+// Details, hints and documentation links are added to the error they belong to under the keys "detail", "hints"
+// and "docURL".
 func ToJSON(err error, withTrace bool) map[string]any {
 	return ToCustomJSON(err, NewDefaultJSONFormat(FormatOptions{
-		WithTrace:    withTrace,
-		WithExternal: true,
+		WithTrace:     withTrace,
+		WithExternal:  true,
+		RelativePaths: deterministicTrace.Load(),
+		MaskLines:     deterministicTrace.Load(),
 	}))
 }
 
@@ -254,17 +317,28 @@ func ToJSON(err error, withTrace bool) map[string]any {
 //	}
 func ToCustomJSON(err error, format JSONFormat) map[string]any {
 	upErr := Unpack(err)
+	return upErr.formatJSON(format)
+}
 
+// JSON formatter for unpacked errors.
+func (upErr *UnpackedError) formatJSON(format JSONFormat) map[string]any {
 	jsonMap := make(map[string]any)
+	if format.Options.WithSeverity && upErr.sev != 0 {
+		jsonMap["severity"] = upErr.sev.String()
+	}
 	if format.Options.WithExternal && upErr.ErrExternal != nil {
-
-		join, ok := upErr.ErrExternal.(joinError)
-		if !ok {
-			jsonMap["external"] = formatExternalStr(upErr.ErrExternal, format.Options.WithTrace)
+		if len(upErr.Children) == 0 {
+			jsonMap["external"] = fmt.Sprint(upErr.ErrExternal)
+			if format.Options.WithTrace {
+				jsonMap["external"] = fmt.Sprintf("%+v", upErr.ErrExternal)
+			}
 		} else {
+			if ctx := externalContext(upErr.ErrExternal); ctx != "" {
+				jsonMap["external"] = ctx
+			}
 			var externals []map[string]any
-			for _, e := range join.Unwrap() {
-				externals = append(externals, ToCustomJSON(e, format))
+			for _, child := range upErr.Children {
+				externals = append(externals, child.formatJSON(format))
 			}
 			jsonMap["externals"] = externals
 		}
@@ -291,23 +365,43 @@ func ToCustomJSON(err error, format JSONFormat) map[string]any {
 
 // Unpack returns a human-readable UnpackedError type for a given error.
 func Unpack(err error) UnpackedError {
-	var upErr UnpackedError
+	upErr := UnpackedError{sev: GetSeverity(err)}
 	for err != nil {
 		switch err := err.(type) {
 		case *rootError:
 			upErr.ErrRoot.Msg = err.msg
+			upErr.ErrRoot.format = err.format
+			upErr.ErrRoot.args = err.args
+			upErr.ErrRoot.vars = err.vars
 			upErr.ErrRoot.Stack = err.stack.get()
 			upErr.ErrRoot.code = err.code
-			upErr.ErrRoot.kvs = err.kvs
+			upErr.ErrRoot.kvs = mergeKeys(err.kvs, err.keys)
+			upErr.ErrRoot.hints = err.hints
+			upErr.ErrRoot.detail = err.detail
+			upErr.ErrRoot.docURL = err.docURL
+			upErr.ErrRoot.id = err.id
+			upErr.ErrRoot.sev = err.sev
 		case *wrapError:
 			// prepend links in stack trace order
-			link := ErrLink{Msg: err.msg}
+			link := ErrLink{Msg: err.msg, format: err.format, args: err.args, vars: err.vars}
 			link.Frame = err.frame.get()
 			link.code = err.code
-			link.kvs = err.kvs
+			link.kvs = mergeKeys(err.kvs, err.keys)
+			link.hints = err.hints
+			link.detail = err.detail
+			link.docURL = err.docURL
+			link.id = err.id
+			link.sev = err.sev
 			upErr.ErrChain = append([]ErrLink{link}, upErr.ErrChain...)
 		default:
 			upErr.ErrExternal = err
+			if multi, ok := multiError(err); ok {
+				for _, child := range multi.(interface{ Unwrap() []error }).Unwrap() {
+					if child != nil {
+						upErr.Children = append(upErr.Children, Unpack(child))
+					}
+				}
+			}
 			return upErr
 		}
 		err = Unwrap(err)
@@ -315,35 +409,94 @@ func Unpack(err error) UnpackedError {
 	return upErr
 }
 
+// multiError returns the first error implementing `Unwrap() []error` that is found by following `Unwrap() error`
+// from the external error err, e.g. the joined error of fmt.Errorf("ctx: %w", errors.Join(a, b)). The search ends
+// at eris errors.
+func multiError(err error) (error, bool) {
+	for err != nil {
+		switch err.(type) {
+		case *rootError, *wrapError:
+			return nil, false
+		case interface{ Unwrap() []error }:
+			return err, true
+		}
+		err = Unwrap(err)
+	}
+	return nil, false
+}
+
+// externalContext returns the message an external error adds to the multi-error it wraps, e.g. "ctx" for
+// fmt.Errorf("ctx: %w", errors.Join(a, b)). It returns an empty string if the external error is the multi-error
+// itself or if its message doesn't end with the message of the multi-error.
+func externalContext(err error) string {
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		return ""
+	}
+	multi, ok := multiError(err)
+	if !ok {
+		return ""
+	}
+	prefix, ok := strings.CutSuffix(err.Error(), multi.Error())
+	if !ok {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimSpace(prefix), ":")
+}
+
 // UnpackedError represents complete information about an error.
 //
 // This type can be used for custom error logging and parsing. Use `eris.Unpack` to build an UnpackedError
 // from any error type. The ErrChain and ErrRoot fields correspond to `wrapError` and `rootError` types,
 // respectively. If any other error type is unpacked, it will appear in the ExternalErr field.
+//
+// If the external error wraps multiple errors via `Unwrap() []error`, e.g. errors created by `errors.Join` or
+// `eris.Join`, each of them is unpacked into Children, which turns the UnpackedError into a tree. External errors
+// wrapping such an error via `Unwrap() error`, e.g. fmt.Errorf("ctx: %w", errors.Join(a, b)), are followed.
 type UnpackedError struct {
 	ErrExternal error
 	ErrRoot     ErrRoot
 	ErrChain    []ErrLink
+	Children    []UnpackedError
+	sev         Severity // effective severity of the chain, see GetSeverity
 }
 
-// String formatter for external errors.
-func formatExternalStr(err error, withTrace bool) string {
-	type joinError interface {
-		Unwrap() []error
+// KVs returns the key-value pairs of the root error and the chain with sensitive values masked. If a key is set
+// multiple times, the outermost value wins. The KVs of children aren't included.
+func (upErr *UnpackedError) KVs() map[string]any {
+	kvs := make(map[string]any)
+	for k, v := range upErr.ErrRoot.KVs() {
+		kvs[k] = v
 	}
-
-	format := "%v"
-	if withTrace {
-		format = "%+v"
+	for _, eLink := range upErr.ErrChain {
+		for k, v := range eLink.KVs() {
+			kvs[k] = v
+		}
 	}
-	join, ok := err.(joinError)
-	if !ok {
-		return fmt.Sprintf(format, err)
+	return kvs
+}
+
+// String formatter for external errors. Children are rendered one per line with their index as prefix.
+func (upErr *UnpackedError) formatExternalStr(format StringFormat) string {
+	if len(upErr.Children) == 0 {
+		if format.Options.WithTrace {
+			return fmt.Sprintf("%+v", upErr.ErrExternal)
+		}
+		return fmt.Sprint(upErr.ErrExternal)
 	}
 
 	var strs []string
-	for i, e := range join.Unwrap() {
-		lines := strings.Split(fmt.Sprintf(format, e), "\n")
+	if ctx := externalContext(upErr.ErrExternal); ctx != "" {
+		strs = append(strs, ctx)
+	}
+	for i, child := range upErr.Children {
+		var childStr string
+		if len(child.ErrRoot.Stack) == 0 && len(child.ErrChain) == 0 {
+			// plain external errors are printed without the empty root error
+			childStr = child.formatExternalStr(format)
+		} else {
+			childStr = child.formatStr(format)
+		}
+		lines := strings.Split(childStr, "\n")
 		for no, line := range lines {
 			lines[no] = fmt.Sprintf("\t%s", line)
 		}
@@ -354,10 +507,18 @@ func formatExternalStr(err error, withTrace bool) string {
 
 // ErrRoot represents an error stack and the accompanying message.
 type ErrRoot struct {
-	Msg   string
-	Stack Stack
-	code  Code
-	kvs   map[string]any
+	Msg    string
+	Stack  Stack
+	code   Code
+	kvs    map[string]any
+	format string
+	args   []any
+	vars   *msgVariants
+	hints  []string
+	detail string
+	docURL string
+	id     string
+	sev    Severity
 }
 
 // Code returns the error code.
@@ -365,27 +526,89 @@ func (err *ErrRoot) Code() Code {
 	return err.code
 }
 
+// Hints returns the hints of the error.
+func (err *ErrRoot) Hints() []string {
+	return err.hints
+}
+
+// Detail returns the detail of the error.
+func (err *ErrRoot) Detail() string {
+	return err.detail
+}
+
+// DocURL returns the documentation link of the error.
+func (err *ErrRoot) DocURL() string {
+	return err.docURL
+}
+
+// ID returns the ID of the definition the error was created from, or an empty string.
+func (err *ErrRoot) ID() string {
+	return err.id
+}
+
+// Severity returns the severity set on the error, or zero if it has none.
+func (err *ErrRoot) Severity() Severity {
+	return err.sev
+}
+
+// formatSeverityStr returns the severity of the error for string output, or an empty string if it has none.
+func (err *ErrRoot) formatSeverityStr() string {
+	if err.sev == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" severity(%s)", err.sev)
+}
+
+// formatHintsJSON adds the detail, hints and documentation link of the error to a JSON map.
+func (err *ErrRoot) formatHintsJSON(jsonMap map[string]any) {
+	if err.detail != "" {
+		jsonMap["detail"] = err.detail
+	}
+	if len(err.hints) > 0 {
+		jsonMap["hints"] = err.hints
+	}
+	if err.docURL != "" {
+		jsonMap["docURL"] = err.docURL
+	}
+}
+
+// KVs returns the key-value pairs of the error with sensitive values masked.
+func (err *ErrRoot) KVs() map[string]any {
+	return redactKVs(err.kvs, RedactMask)
+}
+
+// Template returns the format the message was created from, e.g. "user %v not found", or the message itself if
+// it was created without arguments. Unlike the message, it doesn't vary with the arguments.
+func (err *ErrRoot) Template() string {
+	return messageTemplate(err.format, err.Msg)
+}
+
 // HasKVs returns true if the error has key-value pairs.
 func (err *ErrRoot) HasKVs() bool {
 	return err.kvs != nil && len(err.kvs) > 0
 }
 
+// message returns the error message with sensitive arguments rendered for the given mode.
+func (err *ErrRoot) message(mode RedactMode) string {
+	return err.vars.message(err.Msg, mode)
+}
+
 // String formatter for root errors.
 func (err *ErrRoot) formatStr(format StringFormat) string {
 
 	kvs := ""
 	if len(err.kvs) > 0 {
-		kvs = fmt.Sprintf(" KVs(%v)", err.kvs)
+		kvs = fmt.Sprintf(" KVs(%v)", redactKVs(err.kvs, format.Options.Redact))
 	}
 
 	// Do not print default errors
-	if kvs == "" && err.code == DEFAULT_ERROR_CODE_NEW && err.Msg == "" {
+	if kvs == "" && err.sev == 0 && err.code == DEFAULT_ERROR_CODE_NEW && err.Msg == "" {
 		return ""
 	}
 
-	str := fmt.Sprintf("code(%s)%s %s%s", err.code.String(), kvs, err.Msg, format.MsgStackSep)
+	str := fmt.Sprintf("code(%s)%s%s %s%s", err.code.String(), err.formatSeverityStr(), kvs, err.message(format.Options.Redact), format.MsgStackSep)
 	if format.Options.WithTrace {
-		stackArr := err.Stack.format(format.StackElemSep, format.Options.InvertTrace)
+		stackArr := err.Stack.format(format.StackElemSep, format.Options)
 		for i, frame := range stackArr {
 			str += format.PreStackSep + frame
 			if i < len(stackArr)-1 {
@@ -400,22 +623,37 @@ func (err *ErrRoot) formatStr(format StringFormat) string {
 func (err *ErrRoot) formatJSON(format JSONFormat) map[string]any {
 	rootMap := make(map[string]any)
 	rootMap["code"] = err.code.String()
-	rootMap["message"] = err.Msg
+	if err.id != "" {
+		rootMap["id"] = err.id
+	}
+	if err.sev != 0 {
+		rootMap["severity"] = err.sev.String()
+	}
+	rootMap["message"] = err.message(format.Options.Redact)
 	if err.HasKVs() {
-		rootMap["KVs"] = err.kvs // TODO: debugging notes we lost the object at this point
+		rootMap["KVs"] = redactKVs(err.kvs, format.Options.Redact) // TODO: debugging notes we lost the object at this point
 	}
+	err.formatHintsJSON(rootMap)
 	if format.Options.WithTrace {
-		rootMap["stack"] = err.Stack.format(format.StackElemSep, format.Options.InvertTrace)
+		rootMap["stack"] = err.Stack.format(format.StackElemSep, format.Options)
 	}
 	return rootMap
 }
 
 // ErrLink represents a single error frame and the accompanying information.
 type ErrLink struct {
-	Msg   string
-	Frame StackFrame
-	code  Code
-	kvs   map[string]any
+	Msg    string
+	Frame  StackFrame
+	code   Code
+	kvs    map[string]any
+	format string
+	args   []any
+	vars   *msgVariants
+	hints  []string
+	detail string
+	docURL string
+	id     string
+	sev    Severity
 }
 
 // Code returns the error code.
@@ -423,20 +661,90 @@ func (eLink *ErrLink) Code() Code {
 	return eLink.code
 }
 
+// Hints returns the hints of the error.
+func (eLink *ErrLink) Hints() []string {
+	return eLink.hints
+}
+
+// Detail returns the detail of the error.
+func (eLink *ErrLink) Detail() string {
+	return eLink.detail
+}
+
+// DocURL returns the documentation link of the error.
+func (eLink *ErrLink) DocURL() string {
+	return eLink.docURL
+}
+
+// ID returns the ID of the definition the error was created from, or an empty string.
+func (eLink *ErrLink) ID() string {
+	return eLink.id
+}
+
+// Severity returns the severity set on the error, or zero if it has none.
+func (eLink *ErrLink) Severity() Severity {
+	return eLink.sev
+}
+
+// formatSeverityStr returns the severity of the error for string output, or an empty string if it has none.
+func (eLink *ErrLink) formatSeverityStr() string {
+	if eLink.sev == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" severity(%s)", eLink.sev)
+}
+
+// formatHintsJSON adds the detail, hints and documentation link of the error to a JSON map.
+func (eLink *ErrLink) formatHintsJSON(jsonMap map[string]any) {
+	if eLink.detail != "" {
+		jsonMap["detail"] = eLink.detail
+	}
+	if len(eLink.hints) > 0 {
+		jsonMap["hints"] = eLink.hints
+	}
+	if eLink.docURL != "" {
+		jsonMap["docURL"] = eLink.docURL
+	}
+}
+
+// KVs returns the key-value pairs of the error with sensitive values masked.
+func (eLink *ErrLink) KVs() map[string]any {
+	return redactKVs(eLink.kvs, RedactMask)
+}
+
+// Template returns the format the message was created from, e.g. "user %v not found", or the message itself if
+// it was created without arguments. Unlike the message, it doesn't vary with the arguments.
+func (eLink *ErrLink) Template() string {
+	return messageTemplate(eLink.format, eLink.Msg)
+}
+
 // HasKVs returns true if the error has key-value pairs.
 func (eLink *ErrLink) HasKVs() bool {
 	return eLink.kvs != nil && len(eLink.kvs) > 0
 }
 
+// message returns the error message with sensitive arguments rendered for the given mode.
+func (eLink *ErrLink) message(mode RedactMode) string {
+	return eLink.vars.message(eLink.Msg, mode)
+}
+
+// messageTemplate returns the format of a message, or the message itself if it was created without format.
+func messageTemplate(format, msg string) string {
+	if format == "" {
+		return msg
+	}
+	return format
+}
+
 // String formatter for wrap errors chains.
 func (eLink *ErrLink) formatStr(format StringFormat) string {
 	kvs := ""
 	if len(eLink.kvs) > 0 {
-		kvs = fmt.Sprintf(" KVs(%v)", eLink.kvs)
+		kvs = fmt.Sprintf(" KVs(%v)", redactKVs(eLink.kvs, format.Options.Redact))
 	}
-	str := fmt.Sprintf("code(%s)%s %s%s", eLink.code.String(), kvs, eLink.Msg, format.MsgStackSep)
+	str := fmt.Sprintf("code(%s)%s%s %s%s", eLink.code.String(), eLink.formatSeverityStr(), kvs, eLink.message(format.Options.Redact), format.MsgStackSep)
 	if format.Options.WithTrace {
-		str += format.PreStackSep + eLink.Frame.format(format.StackElemSep)
+		str += format.PreStackSep + eLink.Frame.format(format.StackElemSep, format.Options)
 	}
 	return str
 }
@@ -445,12 +753,19 @@ func (eLink *ErrLink) formatStr(format StringFormat) string {
 func (eLink *ErrLink) formatJSON(format JSONFormat) map[string]any {
 	wrapMap := make(map[string]any)
 	wrapMap["code"] = eLink.code.String()
-	wrapMap["message"] = fmt.Sprint(eLink.Msg)
+	if eLink.id != "" {
+		wrapMap["id"] = eLink.id
+	}
+	if eLink.sev != 0 {
+		wrapMap["severity"] = eLink.sev.String()
+	}
+	wrapMap["message"] = eLink.message(format.Options.Redact)
 	if eLink.HasKVs() {
-		wrapMap["KVs"] = eLink.kvs
+		wrapMap["KVs"] = redactKVs(eLink.kvs, format.Options.Redact)
 	}
+	eLink.formatHintsJSON(wrapMap)
 	if format.Options.WithTrace {
-		wrapMap["stack"] = eLink.Frame.format(format.StackElemSep)
+		wrapMap["stack"] = eLink.Frame.format(format.StackElemSep, format.Options)
 	}
 	return wrapMap
 }
@@ -582,3 +582,87 @@ code\(unknown\) join error
 		})
 	}
 }
+
+func TestUnpackTree(t *testing.T) {
+	err := eris.Wrap(eris.Join(
+		errors.New("external error"),
+		eris.Wrap(errors.Join(errors.New("nested 1"), eris.New("nested 2")), "inner wrap"),
+	), "outer wrap")
+
+	upErr := eris.Unpack(err)
+	if len(upErr.Children) != 2 {
+		t.Fatalf("expected { %v } children got { %v }", 2, len(upErr.Children))
+	}
+	if upErr.Children[0].ErrExternal.Error() != "external error" {
+		t.Errorf("expected { %v } got { %v }", "external error", upErr.Children[0].ErrExternal)
+	}
+	inner := upErr.Children[1]
+	if inner.ErrRoot.Msg != "inner wrap" || len(inner.Children) != 2 {
+		t.Fatalf("expected nested join below { %v } got { %+v }", "inner wrap", inner)
+	}
+	if inner.Children[1].ErrRoot.Msg != "nested 2" {
+		t.Errorf("expected { %v } got { %v }", "nested 2", inner.Children[1].ErrRoot.Msg)
+	}
+
+	expected := `code(internal) outer wrap: code(unknown) join error
+0>	external error
+1>	code(internal) inner wrap
+	0>	nested 1
+	1>	code(unknown) nested 2`
+	if got := eris.ToString(err, false); got != expected {
+		t.Errorf("expected\n%v\ngot\n%v", expected, got)
+	}
+
+	result, _ := json.Marshal(eris.ToJSON(err, false))
+	expectedJSON := `{"externals":[{"external":"external error"},{"externals":[{"external":"nested 1"},{"root":{"code":"unknown","message":"nested 2"}}],"root":{"code":"internal","message":"inner wrap"}}],"root":{"code":"unknown","message":"join error"},"wrap":[{"code":"internal","message":"outer wrap"}]}`
+	if string(result) != expectedJSON {
+		t.Errorf("expected { %v } got { %v }", expectedJSON, string(result))
+	}
+}
+
+func TestUnpackNestedMultiError(t *testing.T) {
+	err := eris.Wrap(fmt.Errorf("ctx: %w", errors.Join(errors.New("a"), eris.New("b"))), "wrap")
+
+	upErr := eris.Unpack(err)
+	if len(upErr.Children) != 2 {
+		t.Fatalf("expected { %v } children got { %v }", 2, len(upErr.Children))
+	}
+	if upErr.Children[0].ErrExternal.Error() != "a" || upErr.Children[1].ErrRoot.Msg != "b" {
+		t.Errorf("unexpected children { %+v }", upErr.Children)
+	}
+
+	expected := "code(internal) wrap\nctx\n0>\ta\n1>\tcode(unknown) b"
+	if got := eris.ToString(err, false); got != expected {
+		t.Errorf("expected\n%v\ngot\n%v", expected, got)
+	}
+	result, _ := json.Marshal(eris.ToJSON(err, false))
+	expectedJSON := `{"external":"ctx","externals":[{"external":"a"},{"root":{"code":"unknown","message":"b"}}],"root":{"code":"internal","message":"wrap"}}`
+	if string(result) != expectedJSON {
+		t.Errorf("expected { %v } got { %v }", expectedJSON, string(result))
+	}
+}
+
+func TestUnpackTemplateKVs(t *testing.T) {
+	err := eris.Wrap(eris.Errorf("user %v not found", eris.Sensitive("alice")).WithProperty("token", eris.Sensitive("secret")), "login failed")
+	upErr := eris.Unpack(err)
+	if template := upErr.ErrRoot.Template(); template != "user %v not found" {
+		t.Errorf("expected root template { %v } got { %v }", "user %v not found", template)
+	}
+	if template := upErr.ErrChain[0].Template(); template != "login failed" {
+		t.Errorf("expected wrap template { %v } got { %v }", "login failed", template)
+	}
+	if kvs := upErr.ErrRoot.KVs(); !reflect.DeepEqual(kvs, map[string]any{"token": "[redacted]"}) {
+		t.Errorf("expected masked KVs got { %v }", kvs)
+	}
+	if kvs := upErr.ErrChain[0].KVs(); kvs != nil {
+		t.Errorf("expected no wrap KVs got { %v }", kvs)
+	}
+	chained := eris.Unpack(eris.WithProperty(eris.Wrap(eris.WithProperty(err, "user", "bob"), "retry"), "user", eris.Sensitive("carol")))
+	if kvs := chained.KVs(); !reflect.DeepEqual(kvs, map[string]any{"token": "[redacted]", "user": "[redacted]"}) {
+		t.Errorf("expected outermost masked chain KVs got { %v }", kvs)
+	}
+	frame := upErr.ErrChain[0].Frame
+	if path := frame.RelativePath(); path != "format_test.go" || frame.Package != "github.com/risingwavelabs/eris" {
+		t.Errorf("expected relative path { %v } got { %v } in { %v }", "format_test.go", path, frame.Package)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/risingwavelabs/eris
 
-go 1.18
+go 1.20
 
 require google.golang.org/grpc v1.53.0
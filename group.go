@@ -0,0 +1,144 @@
+package eris
+
+import (
+	"context"
+	"sync"
+)
+
+// GroupMode defines which error Group.Wait returns.
+type GroupMode uint8
+
+const (
+	// FirstError makes Wait return the first error. The first error also cancels the context of the group.
+	FirstError GroupMode = iota
+	// AllErrors makes Wait return all errors joined into one, see Multi. Errors don't cancel the context.
+	AllErrors
+)
+
+// Group is a collection of goroutines working on subtasks of a common task, like `errgroup.Group` of
+// golang.org/x/sync.
+//
+// Errors returned by the goroutines are wrapped with the frame where the goroutine was spawned, so the trace
+// shows where the work was launched and not only the stack of the goroutine itself. Panics in the goroutines
+// are recovered and returned as errors with code 'internal'.
+//
+// A zero Group is valid, has no limit on the number of active goroutines and does not cancel on error.
+type Group struct {
+	mode   GroupMode
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	errOnce sync.Once
+	err     error
+	errs    Multi
+}
+
+// NewGroup returns a new Group and an associated context derived from ctx.
+//
+// The derived context is canceled the first time a goroutine returns an error in FirstError mode, or the first
+// time Wait returns, whichever occurs first.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetMode sets which error Wait returns. It must not be called while goroutines are active.
+func (g *Group) SetMode(mode GroupMode) {
+	g.mode = mode
+}
+
+// SetLimit limits the number of active goroutines in this group to at most n. A negative value indicates no
+// limit. It must not be called while goroutines are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go calls the given function in a new goroutine. It blocks until the new goroutine can be added without
+// the number of active goroutines in the group exceeding the configured limit.
+func (g *Group) Go(f func() error) {
+	// callers(3) skips runtime.Callers, stack.callers and this method
+	spawn := callers(3)
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.start(f, spawn)
+}
+
+// TryGo calls the given function in a new goroutine only if the number of active goroutines in the group is
+// currently below the configured limit. The return value reports whether the goroutine was started.
+func (g *Group) TryGo(f func() error) bool {
+	spawn := callers(3)
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(f, spawn)
+	return true
+}
+
+// Wait blocks until all function calls from the Go method have returned, then returns the first error or all
+// errors joined, depending on the mode of the group.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	if g.mode == AllErrors {
+		return g.errs.ErrorOrNil()
+	}
+	return g.err
+}
+
+// start runs f in a new goroutine and records its error.
+func (g *Group) start(f func() error, spawn *stack) {
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := runRecovered(f); err != nil {
+			g.fail(wrapAt(err, "goroutine failed", spawn))
+		}
+	}()
+}
+
+// done releases the resources of a finished goroutine.
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// fail records the error of a goroutine.
+func (g *Group) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		if g.mode == FirstError && g.cancel != nil {
+			g.cancel(err)
+		}
+	})
+	if g.mode == AllErrors {
+		g.errs.Append(err)
+	}
+}
+
+// runRecovered calls f and converts a panic into an error.
+func runRecovered(f func() error) error {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = newPanicError(r)
+			}
+		}()
+		err = f()
+	}()
+	return err
+}
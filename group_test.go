@@ -0,0 +1,118 @@
+package eris_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestGroupFirstError(t *testing.T) {
+	g, ctx := eris.NewGroup(context.Background())
+	g.Go(func() error {
+		return eris.New("task failed").WithCode(eris.CodeNotFound)
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	err := g.Wait()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if code := eris.GetCode(err); code != eris.CodeNotFound {
+		t.Errorf("expected code { %v } got { %v }", eris.CodeNotFound, code)
+	}
+	upErr := eris.Unpack(err)
+	if len(upErr.ErrChain) != 1 || upErr.ErrChain[0].Msg != "goroutine failed" {
+		t.Fatalf("expected error to be wrapped with the spawn site, got { %v }", err)
+	}
+	if name := upErr.ErrChain[0].Frame.Name; name != "eris_test.TestGroupFirstError" {
+		t.Errorf("expected spawn frame { %v } got { %v }", "eris_test.TestGroupFirstError", name)
+	}
+	if !errors.Is(context.Cause(ctx), err) {
+		t.Errorf("expected context to be canceled with { %v } got { %v }", err, context.Cause(ctx))
+	}
+}
+
+func TestGroupAllErrors(t *testing.T) {
+	var g eris.Group
+	g.SetMode(eris.AllErrors)
+	ext := errors.New("external error")
+	g.Go(func() error { return ext })
+	g.Go(func() error { return eris.New("task failed").WithCode(eris.CodeUnavailable) })
+	g.Go(func() error { return nil })
+	err := g.Wait()
+
+	if children := eris.Unpack(err).Children; len(children) != 2 {
+		t.Fatalf("expected { %v } errors got { %v }", 2, err)
+	}
+	if !eris.Is(err, ext) {
+		t.Errorf("expected external error to be reachable via Is")
+	}
+	if code := eris.GetCode(err); code != eris.CodeUnknown {
+		t.Errorf("expected code { %v } got { %v }", eris.CodeUnknown, code)
+	}
+	view, ok := eris.Find(err, func(view eris.ErrView) bool { return view.Err == ext })
+	if !ok || view.Code != eris.CodeUnknown {
+		t.Errorf("expected to find external error, got { %+v }", view)
+	}
+}
+
+func TestGroupPanic(t *testing.T) {
+	var g eris.Group
+	g.Go(func() error {
+		var m map[string]int
+		m["key"] = 1 // panics, assignment to nil map
+		return nil
+	})
+	err := g.Wait()
+	if code := eris.GetCode(err); code != eris.CodeInternal {
+		t.Errorf("expected code { %v } got { %v }", eris.CodeInternal, code)
+	}
+	var rtErr runtime.Error
+	if !eris.As(err, &rtErr) {
+		t.Errorf("expected runtime error to be reachable via As, got { %v }", err)
+	}
+	stack := eris.Unpack(err).ErrRoot.Stack
+	if len(stack) == 0 || stack[0].Name != "eris_test.TestGroupPanic.func1" {
+		t.Errorf("expected stack to start at the panic site, got { %v }", stack)
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	var g eris.Group
+	g.SetLimit(1)
+	var active, maxActive int32
+	block := make(chan struct{})
+	g.Go(func() error {
+		<-block
+		return nil
+	})
+	if g.TryGo(func() error { return nil }) {
+		t.Errorf("expected TryGo to fail while the limit is reached")
+	}
+	close(block)
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Errorf("expected no error got { %v }", err)
+	}
+	if maxActive > 1 {
+		t.Errorf("expected at most { %v } active goroutines got { %v }", 1, maxActive)
+	}
+}
@@ -0,0 +1,86 @@
+package eris
+
+// Hints returns a Field of HintType.
+func Hints(hint string) Field {
+	return Field{
+		Type:  HintType,
+		Value: hint,
+	}
+}
+
+// Details returns a Field of DetailType.
+func Details(detail string) Field {
+	return Field{
+		Type:  DetailType,
+		Value: detail,
+	}
+}
+
+// DocURLs returns a Field of DocURLType.
+func DocURLs(url string) Field {
+	return Field{
+		Type:  DocURLType,
+		Value: url,
+	}
+}
+
+// WithHint attaches an actionable suggestion for the user to an error.
+func WithHint(err error, hint string) error {
+	return With(err, Hints(hint))
+}
+
+// WithDetail attaches additional information about an error, e.g. which value violated a constraint.
+func WithDetail(err error, detail string) error {
+	return With(err, Details(detail))
+}
+
+// WithDocURL attaches a link to the documentation of an error.
+func WithDocURL(err error, url string) error {
+	return With(err, DocURLs(url))
+}
+
+// GetHints returns the hints of all errors in the chain, outermost first. Like the detail and the documentation
+// link, hints are stored separately from the KVs.
+func GetHints(err error) []string {
+	type Hinter interface {
+		Hints() []string
+	}
+	var hints []string
+	walk(err, func(err error) bool {
+		if hintErr, ok := err.(Hinter); ok {
+			hints = append(hints, hintErr.Hints()...)
+		}
+		return true
+	})
+	return hints
+}
+
+// GetDetail returns the detail of the outermost error in the chain that has one.
+func GetDetail(err error) string {
+	type Detailer interface {
+		Detail() string
+	}
+	var detail string
+	walk(err, func(err error) bool {
+		if detailErr, ok := err.(Detailer); ok {
+			detail = detailErr.Detail()
+		}
+		return detail == ""
+	})
+	return detail
+}
+
+// GetDocURL returns the documentation link of the outermost error in the chain that has one.
+func GetDocURL(err error) string {
+	type DocURLer interface {
+		DocURL() string
+	}
+	var url string
+	walk(err, func(err error) bool {
+		if urlErr, ok := err.(DocURLer); ok {
+			url = urlErr.DocURL()
+		}
+		return url == ""
+	})
+	return url
+}
@@ -0,0 +1,103 @@
+package eris_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+var errGlobalHint = eris.New("global error").WithHint("global hint")
+
+func TestHints(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		hints  []string
+		detail string
+		docURL string
+	}{
+		"none": {
+			err: eris.New("no hints"),
+		},
+		"root": {
+			err:    eris.New("root").WithHint("first").WithHint("second").WithDetail("detail").WithDocURL("https://doc"),
+			hints:  []string{"first", "second"},
+			detail: "detail",
+			docURL: "https://doc",
+		},
+		"chain": {
+			err:    eris.WithDetail(eris.WithHint(eris.Wrap(eris.New("root").WithHint("inner").WithDetail("inner detail"), "wrap"), "outer"), "outer detail"),
+			hints:  []string{"outer", "inner"},
+			detail: "outer detail",
+		},
+		"package functions": {
+			err:    eris.WithDocURL(eris.WithDetail(eris.WithHint(errors.New("external"), "hint"), "detail"), "https://doc"),
+			hints:  []string{"hint"},
+			detail: "detail",
+			docURL: "https://doc",
+		},
+		"fields": {
+			err:    eris.With(eris.New("root"), eris.Hints("hint"), eris.Details("detail"), eris.DocURLs("https://doc")),
+			hints:  []string{"hint"},
+			detail: "detail",
+			docURL: "https://doc",
+		},
+		"global": {
+			err:   eris.Wrap(errGlobalHint, "wrap"),
+			hints: []string{"global hint"},
+		},
+		"joined": {
+			err:   eris.Join(eris.New("first").WithHint("first hint"), eris.New("second").WithHint("second hint")),
+			hints: []string{"first hint", "second hint"},
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if hints := eris.GetHints(tc.err); !reflect.DeepEqual(hints, tc.hints) {
+				t.Errorf("%v: expected hints { %v } got { %v }", desc, tc.hints, hints)
+			}
+			if detail := eris.GetDetail(tc.err); detail != tc.detail {
+				t.Errorf("%v: expected detail { %v } got { %v }", desc, tc.detail, detail)
+			}
+			if docURL := eris.GetDocURL(tc.err); docURL != tc.docURL {
+				t.Errorf("%v: expected doc URL { %v } got { %v }", desc, tc.docURL, docURL)
+			}
+			if eris.HasProperty(tc.err, "hint") {
+				t.Errorf("%v: expected hints to be stored separately from KVs", desc)
+			}
+		})
+	}
+}
+
+func TestHintsOutput(t *testing.T) {
+	err := eris.WithDocURL(eris.Wrap(eris.New(`column "nme" does not exist`).WithCode(eris.CodeInvalidArgument).
+		WithDetail(`table "users" has columns "id" and "name"`).
+		WithHint(`perhaps you meant to reference the column "name"`), "query failed"),
+		"https://docs.example.com/errors/undefined-column")
+
+	expected := "code(internal) query failed: code(invalid argument) column \"nme\" does not exist" +
+		"\n\tDETAIL: table \"users\" has columns \"id\" and \"name\"" +
+		"\n\tHINT: perhaps you meant to reference the column \"name\"" +
+		"\n\tDOC: https://docs.example.com/errors/undefined-column"
+	if str := eris.ToString(err, false); str != expected {
+		t.Errorf("expected\n%v\ngot\n%v", expected, str)
+	}
+	if strings.Contains(err.Error(), "\n") {
+		t.Errorf("expected Error() to stay on a single line got { %v }", err.Error())
+	}
+
+	errJSON := eris.ToJSON(err, false)
+	root := errJSON["root"].(map[string]any)
+	if root["detail"] != `table "users" has columns "id" and "name"` {
+		t.Errorf("expected detail in root got { %v }", root)
+	}
+	if hints, ok := root["hints"].([]string); !ok || len(hints) != 1 {
+		t.Errorf("expected hints in root got { %v }", root)
+	}
+	wrap := errJSON["wrap"].([]map[string]any)[0]
+	if wrap["docURL"] != "https://docs.example.com/errors/undefined-column" {
+		t.Errorf("expected doc URL in wrap got { %v }", wrap)
+	}
+}
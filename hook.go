@@ -0,0 +1,133 @@
+package eris
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Op is the operation that triggered an ErrorEvent.
+type Op uint8
+
+const (
+	// OpNew is a new root error created via New, Errorf or a definition.
+	OpNew Op = iota + 1
+	// OpWrap is an error wrapped via Wrap or one of its variants.
+	OpWrap
+	// OpWith is an error whose fields were set via With or one of its variants, e.g. WithCode, or whose code was
+	// set via the WithCode method of the error, e.g. eris.New("failed").WithCode(eris.CodeNotFound).
+	OpWith
+)
+
+// String returns the name of the operation.
+func (op Op) String() string {
+	switch op {
+	case OpNew:
+		return "new"
+	case OpWrap:
+		return "wrap"
+	case OpWith:
+		return "with"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorEvent describes the creation or modification of an error, see OnCreate.
+type ErrorEvent struct {
+	Op       Op         // Operation that triggered the event.
+	Err      error      // The created error.
+	Code     Code       // Code of the error at the time of the event.
+	Template string     // Format the message was created from, or the message itself, see ErrRoot.Template.
+	Frame    StackFrame // Frame where the error was created or wrapped.
+	Origin   StackFrame // Frame where the root error of the chain was created, the same as Frame if Root is set.
+	Root     bool       // Whether Err is a root error, i.e. created via New or Errorf, or via Wrap of an external error.
+}
+
+// createHook is a hook registered via OnCreate. Hooks are stored as pointers so they can be removed again.
+type createHook struct {
+	fn func(ErrorEvent)
+}
+
+var (
+	createHooksMu sync.Mutex
+	createHooks   atomic.Pointer[[]*createHook] // nil if no hooks are registered
+)
+
+// OnCreate registers a hook that is called synchronously whenever an error is created via New or Errorf,
+// wrapped via Wrap or modified via With or the WithCode methods, including their variants. It returns a function
+// that removes the hook.
+//
+// Hooks are meant for metrics and must be fast and must not panic. When no hooks are registered, calling them
+// costs a single atomic load. Note that the code of an event is the code at the time of the event, so
+// eris.New("failed").WithCode(eris.CodeNotFound) triggers an event with OpNew and code 'unknown' followed by an
+// event with OpWith and code 'not found'. Hooks counting errors should count the events of root errors only, i.e.
+// with OpNew or with OpWrap and Root set, and read the code from Err once the error is complete, since it is
+// modified in place.
+func OnCreate(fn func(ErrorEvent)) func() {
+	hook := &createHook{fn: fn}
+	createHooksMu.Lock()
+	defer createHooksMu.Unlock()
+	var hooks []*createHook
+	if current := createHooks.Load(); current != nil {
+		hooks = append(hooks, *current...)
+	}
+	hooks = append(hooks, hook)
+	createHooks.Store(&hooks)
+
+	return func() {
+		createHooksMu.Lock()
+		defer createHooksMu.Unlock()
+		current := createHooks.Load()
+		if current == nil {
+			return
+		}
+		var remaining []*createHook
+		for _, h := range *current {
+			if h != hook {
+				remaining = append(remaining, h)
+			}
+		}
+		if len(remaining) == 0 {
+			createHooks.Store(nil)
+		} else {
+			createHooks.Store(&remaining)
+		}
+	}
+}
+
+// notifyCreate calls the registered hooks for an error created or modified by op.
+func notifyCreate(op Op, err error) {
+	hooks := createHooks.Load()
+	if hooks == nil {
+		return
+	}
+	event := ErrorEvent{Op: op, Err: err, Code: GetCode(err)}
+	switch e := err.(type) {
+	case *rootError:
+		event.Template = messageTemplate(e.format, e.msg)
+		event.Frame = e.origin()
+		event.Origin = event.Frame
+		event.Root = true
+	case *wrapError:
+		event.Template = messageTemplate(e.format, e.msg)
+		event.Frame = e.frame.get()
+		walk(e, func(err error) bool {
+			if root, ok := err.(*rootError); ok {
+				event.Origin = root.origin()
+				return false
+			}
+			return true
+		})
+	}
+	for _, hook := range *hooks {
+		hook.fn(event)
+	}
+}
+
+// origin returns the frame where the root error was created.
+func (e *rootError) origin() StackFrame {
+	if e.stack == nil || len(*e.stack) == 0 {
+		return StackFrame{}
+	}
+	return frame((*e.stack)[0]).get()
+}
@@ -0,0 +1,144 @@
+package eris_test
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestOnCreate(t *testing.T) {
+	var events []eris.ErrorEvent
+	remove := eris.OnCreate(func(event eris.ErrorEvent) {
+		events = append(events, event)
+	})
+	defer remove()
+
+	tests := map[string]struct {
+		create   func() error
+		op       eris.Op
+		code     eris.Code
+		template string
+	}{
+		"new": {
+			create:   func() error { return eris.New("root") },
+			op:       eris.OpNew,
+			code:     eris.CodeUnknown,
+			template: "root",
+		},
+		"errorf": {
+			create:   func() error { return eris.Errorf("table %v not found", "users") },
+			op:       eris.OpNew,
+			code:     eris.CodeUnknown,
+			template: "table %v not found",
+		},
+		"definition": {
+			create:   func() error { return eris.Define("hook.missing", eris.CodeNotFound, "%v is missing").New("users") },
+			op:       eris.OpNew,
+			code:     eris.CodeNotFound,
+			template: "%v is missing",
+		},
+		"wrap external": {
+			create:   func() error { return eris.Wrapf(io.EOF, "failed to read %v", "users") },
+			op:       eris.OpWrap,
+			code:     eris.CodeInternal,
+			template: "failed to read %v",
+		},
+		"with code method": {
+			create:   func() error { return eris.New("root").WithCode(eris.CodeNotFound) },
+			op:       eris.OpWith,
+			code:     eris.CodeNotFound,
+			template: "root",
+		},
+		"with": {
+			create:   func() error { return eris.WithCode(eris.Wrap(io.EOF, "read"), eris.CodeDataLoss) },
+			op:       eris.OpWith,
+			code:     eris.CodeDataLoss,
+			template: "read",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			events = nil
+			err := tc.create()
+			if len(events) == 0 {
+				t.Fatalf("%v: expected events", desc)
+			}
+			event := events[len(events)-1]
+			if event.Op != tc.op || event.Code != tc.code || event.Template != tc.template || event.Err != err {
+				t.Errorf("%v: expected { %v %v %q } got { %v %v %q }", desc, tc.op, tc.code, tc.template, event.Op, event.Code, event.Template)
+			}
+			if !strings.HasPrefix(event.Frame.Name, "eris_test.TestOnCreate.func") || event.Frame.Package != "github.com/risingwavelabs/eris" {
+				t.Errorf("%v: unexpected frame { %+v }", desc, event.Frame)
+			}
+		})
+	}
+
+	events = nil
+	err := eris.Wrap(eris.New("root"), "wrap")
+	if len(events) != 2 || events[1].Op != eris.OpWrap || events[1].Err != err || events[1].Frame.Name != "eris_test.TestOnCreate" {
+		t.Errorf("expected wrap event got { %+v }", events)
+	}
+	if !events[0].Root || events[1].Root || events[1].Origin != events[0].Frame {
+		t.Errorf("expected wrap event of a non-root error with the origin of the root error got { %+v }", events)
+	}
+
+	events = nil
+	err = eris.Wrap(io.EOF, "wrap")
+	if len(events) != 1 || events[0].Op != eris.OpWrap || !events[0].Root || events[0].Origin != events[0].Frame {
+		t.Errorf("expected wrap event of a root error got { %+v }", events)
+	}
+
+	events = nil
+	err = eris.With(eris.New("root"), eris.Codes(eris.CodeNotFound), eris.KVs("table", "users"))
+	if len(events) != 2 || events[1].Op != eris.OpWith || events[1].Code != eris.CodeNotFound {
+		t.Errorf("expected a single with event got { %+v }", events)
+	}
+
+	remove()
+	events = nil
+	_ = eris.New("root")
+	if len(events) != 0 {
+		t.Errorf("expected no events after removing the hook got { %v }", events)
+	}
+	remove()
+}
+
+func TestOnCreateConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	counts := map[eris.Op]int{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			remove := eris.OnCreate(func(event eris.ErrorEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				counts[event.Op]++
+			})
+			_ = eris.Wrap(eris.New("root"), "wrap")
+			remove()
+		}()
+	}
+	wg.Wait()
+	if counts[eris.OpNew] == 0 || counts[eris.OpNew] != counts[eris.OpWrap] {
+		t.Errorf("expected as many new as wrap events got { %v }", counts)
+	}
+}
+
+func TestOpString(t *testing.T) {
+	for op, str := range map[eris.Op]string{eris.OpNew: "new", eris.OpWrap: "wrap", eris.OpWith: "with", 0: "unknown"} {
+		if op.String() != str {
+			t.Errorf("expected { %v } got { %v }", str, op.String())
+		}
+	}
+}
+
+func BenchmarkNewWithoutHooks(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = eris.New("root")
+	}
+}
@@ -0,0 +1,123 @@
+package eris
+
+// Key is a typed property key. Using a Key instead of a plain string ensures at compile time that a property
+// is always set and read with the same type.
+//
+// Each call of NewKey returns a distinct key, even for the same name, so keys of different packages don't collide.
+// Declare keys as package level variables to let a package own them:
+//
+//	var TableKey = eris.NewKey[string]("table")
+//
+// The name is only used for output: properties of keys are included under their name in the KVs of the error,
+// e.g. in ToJSON output and GetKVs, where KVs set via WithProperty take precedence over keys of the same name.
+// A key doesn't read KVs set via WithProperty.
+type Key[T any] struct {
+	id *keyID
+}
+
+// keyID identifies a key created by NewKey.
+type keyID struct {
+	name string
+}
+
+// keyValue is the value of a property set via a Key.
+type keyValue struct {
+	id    *keyID
+	value any
+}
+
+// NewKey returns a new typed property key with the given name.
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{id: &keyID{name: name}}
+}
+
+// Name returns the name under which the property is rendered.
+func (k Key[T]) Name() string {
+	return k.id.name
+}
+
+// String returns the name of the key.
+func (k Key[T]) String() string {
+	return k.id.name
+}
+
+// Get returns the property of the key. If the property doesn't exist, returns T{}, false.
+func (k Key[T]) Get(err error) (T, bool) {
+	return k.lookup(keyValues(err))
+}
+
+// Find returns the property of the key from the outermost error in the chain that sets it.
+// If the property doesn't exist, returns T{}, false.
+func (k Key[T]) Find(err error) (T, bool) {
+	var value T
+	var found bool
+	walk(err, func(err error) bool {
+		value, found = k.lookup(keyValues(err))
+		return !found
+	})
+	return value, found
+}
+
+// Field returns a Field of KeyType setting the key to value.
+func (k Key[T]) Field(value T) Field {
+	return Field{
+		Type:  KeyType,
+		Key:   k.id.name,
+		Value: keyValue{id: k.id, value: value},
+	}
+}
+
+// lookup returns the value of the key in keys.
+func (k Key[T]) lookup(keys []keyValue) (T, bool) {
+	for _, kv := range keys {
+		if kv.id == k.id {
+			typed, ok := kv.value.(T)
+			return typed, ok
+		}
+	}
+	var empty T
+	return empty, false
+}
+
+// WithKey attaches a typed key-value property for an error.
+func WithKey[T any](err error, key Key[T], value T) error {
+	return With(err, key.Field(value))
+}
+
+// keyValues returns the properties set via keys on a single error of a chain.
+func keyValues(err error) []keyValue {
+	switch err := err.(type) {
+	case *rootError:
+		return err.keys
+	case *wrapError:
+		return err.keys
+	}
+	return nil
+}
+
+// setKeyValue sets the property of a key, replacing a previous value of the same key.
+func setKeyValue(keys []keyValue, kv keyValue) []keyValue {
+	for i := range keys {
+		if keys[i].id == kv.id {
+			keys[i] = kv
+			return keys
+		}
+	}
+	return append(keys, kv)
+}
+
+// mergeKeys returns the KVs with the properties of keys added under their names. KVs take precedence over keys of
+// the same name, and keys set later over earlier ones.
+func mergeKeys(kvs map[string]any, keys []keyValue) map[string]any {
+	if len(keys) == 0 {
+		return kvs
+	}
+	merged := make(map[string]any, len(kvs)+len(keys))
+	for _, kv := range keys {
+		merged[kv.id.name] = kv.value
+	}
+	for k, v := range kvs {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,89 @@
+package eris_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+var (
+	tableKey = eris.NewKey[string]("table")
+	rowsKey  = eris.NewKey[int]("rows")
+)
+
+func TestKey(t *testing.T) {
+	tests := map[string]struct {
+		cause error
+		table string
+		exist bool
+	}{
+		"no property": {
+			cause: eris.New("error message"),
+		},
+		"key found": {
+			cause: eris.WithKey(eris.New("error message"), tableKey, "t1"),
+			table: "t1",
+			exist: true,
+		},
+		"key set via field": {
+			cause: eris.With(eris.New("error message"), tableKey.Field("t1")),
+			table: "t1",
+			exist: true,
+		},
+		"string property of the same name": {
+			cause: eris.WithProperty(eris.New("error message"), "table", "t1"),
+		},
+		"other key of the same name": {
+			cause: eris.WithKey(eris.New("error message"), eris.NewKey[string]("table"), "t1"),
+		},
+		"key on external error": {
+			cause: eris.WithKey(errors.New("external error"), tableKey, "t1"),
+			table: "t1",
+			exist: true,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			table, ok := tableKey.Get(tc.cause)
+			if ok != tc.exist {
+				t.Errorf("%v: expected ok { %v } got { %v }", desc, tc.exist, ok)
+			}
+			if table != tc.table {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.table, table)
+			}
+		})
+	}
+}
+
+func TestKeyCollision(t *testing.T) {
+	intID := eris.NewKey[int]("id")
+	stringID := eris.NewKey[string]("id")
+	err := eris.WithKey(eris.WithKey(eris.New("error message"), intID, 1), stringID, "a")
+	if id, ok := intID.Get(err); !ok || id != 1 {
+		t.Errorf("expected { %v } got { %v, %v }", 1, id, ok)
+	}
+	if id, ok := stringID.Get(err); !ok || id != "a" {
+		t.Errorf("expected { %v } got { %v, %v }", "a", id, ok)
+	}
+	err = eris.Wrap(eris.WithKey(err, intID, 2), "wrap")
+	if id, ok := intID.Find(err); !ok || id != 2 {
+		t.Errorf("expected { %v } got { %v, %v }", 2, id, ok)
+	}
+	if id, ok := stringID.Find(err); !ok || id != "a" {
+		t.Errorf("expected { %v } got { %v, %v }", "a", id, ok)
+	}
+}
+
+func TestKeyJSON(t *testing.T) {
+	err := eris.WithProperty(eris.WithKey(eris.New("root error"), rowsKey, 3), "table", "t1")
+	result, _ := json.Marshal(eris.ToJSON(err, false))
+	expected := `{"root":{"KVs":{"rows":3,"table":"t1"},"code":"unknown","message":"root error"}}`
+	if string(result) != expected {
+		t.Errorf("expected { %v } got { %v }", expected, string(result))
+	}
+	if rows, ok := eris.GetProperty[int](err, rowsKey.Name()); !ok || rows != 3 {
+		t.Errorf("expected { %v } got { %v, %v }", 3, rows, ok)
+	}
+}
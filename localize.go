@@ -0,0 +1,260 @@
+package eris
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Translator translates message keys into localized message formats.
+//
+// The key of a message is the ID of its definition (see Define), or otherwise its format string, like msgid in
+// gettext. The translation is a format string applied to the original arguments, so translations can reorder
+// arguments via explicit indexes, e.g. "%[2]v: %[1]v".
+type Translator interface {
+	Translate(lang, key string) (string, bool)
+}
+
+var (
+	translatorMu sync.RWMutex
+	translator   Translator
+)
+
+// SetTranslator sets the translator used by Localize and ToStringLocalized.
+func SetTranslator(tr Translator) {
+	translatorMu.Lock()
+	defer translatorMu.Unlock()
+	translator = tr
+}
+
+// currentTranslator returns the translator set via SetTranslator.
+func currentTranslator() Translator {
+	translatorMu.RLock()
+	defer translatorMu.RUnlock()
+	return translator
+}
+
+// Localize returns the messages of the chain translated into the given language and joined by ": ", without codes
+// and KVs. Messages without translation fall back to the original message.
+func Localize(err error, lang string) string {
+	upErr := Unpack(err)
+	upErr.localize(lang, currentTranslator())
+	return upErr.localizedMessage()
+}
+
+// localizedMessage returns the messages of the localized unpacked error joined by ": ".
+func (upErr *UnpackedError) localizedMessage() string {
+	var msgs []string
+	for i := len(upErr.ErrChain) - 1; i >= 0; i-- {
+		msgs = append(msgs, upErr.ErrChain[i].message(RedactMask))
+	}
+	if msg := upErr.ErrRoot.message(RedactMask); msg != "" {
+		msgs = append(msgs, msg)
+	}
+	if upErr.ErrExternal != nil {
+		msgs = append(msgs, upErr.localizedExternal())
+	}
+	return strings.Join(msgs, ": ")
+}
+
+// localizedExternal returns the message of the external error. The messages of joined errors are rendered from
+// the localized children one per line, like errors.Join does.
+func (upErr *UnpackedError) localizedExternal() string {
+	if len(upErr.Children) == 0 {
+		return upErr.ErrExternal.Error()
+	}
+	children := make([]string, len(upErr.Children))
+	for i := range upErr.Children {
+		children[i] = upErr.Children[i].localizedMessage()
+	}
+	msg := strings.Join(children, "\n")
+	if ctx := externalContext(upErr.ErrExternal); ctx != "" {
+		msg = ctx + ": " + msg
+	}
+	return msg
+}
+
+// ToStringLocalized returns a default formatted string for a given error like ToString, with all messages
+// translated into the given language. Messages without translation fall back to the original message.
+func ToStringLocalized(err error, lang string, withTrace bool) string {
+	upErr := Unpack(err)
+	upErr.localize(lang, currentTranslator())
+	return upErr.formatStr(NewDefaultStringFormat(FormatOptions{
+		WithTrace:    withTrace,
+		WithExternal: true,
+		WithHints:    true,
+	}))
+}
+
+// localize translates the messages of all errors in the unpacked error, including children.
+func (upErr *UnpackedError) localize(lang string, tr Translator) {
+	if tr == nil {
+		return
+	}
+	root := &upErr.ErrRoot
+	root.Msg, root.format, root.vars = translate(tr, lang, root.id, root.Msg, root.format, root.args, root.vars)
+	for i := range upErr.ErrChain {
+		link := &upErr.ErrChain[i]
+		link.Msg, link.format, link.vars = translate(tr, lang, link.id, link.Msg, link.format, link.args, link.vars)
+	}
+	for i := range upErr.Children {
+		upErr.Children[i].localize(lang, tr)
+	}
+}
+
+// translate returns the translated message, format and message variants of a single error. The key is the ID,
+// the format or the message, whichever is set first. Translations of formatted messages are format strings even if
+// there are no arguments, e.g. "100%% fertig".
+func translate(tr Translator, lang, id, msg, format string, args []any, vars *msgVariants) (string, string, *msgVariants) {
+	if msg == "" && format == "" {
+		return msg, format, vars
+	}
+	var translated string
+	var ok bool
+	if id != "" {
+		translated, ok = tr.Translate(lang, id)
+	}
+	if !ok && format != "" {
+		translated, ok = tr.Translate(lang, format)
+	}
+	if !ok && format == "" {
+		translated, ok = tr.Translate(lang, msg)
+	}
+	if !ok {
+		return msg, format, vars
+	}
+	if format == "" {
+		// static messages are translated literally
+		return translated, format, vars
+	}
+	return formatMsg(translated, args, RedactMask), translated, newMsgVariants(translated, args)
+}
+
+// MessageCatalog is a Translator backed by in-memory translations per language. Lookups for regional languages
+// like "de-CH" fall back to the base language "de". It is safe for concurrent use.
+type MessageCatalog struct {
+	mu           sync.RWMutex
+	translations map[string]map[string]string
+}
+
+// NewMessageCatalog returns an empty message catalog.
+func NewMessageCatalog() *MessageCatalog {
+	return &MessageCatalog{translations: make(map[string]map[string]string)}
+}
+
+// Add adds the translation of a message key for a language.
+func (c *MessageCatalog) Add(lang, key, translation string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.translations[lang] == nil {
+		c.translations[lang] = make(map[string]string)
+	}
+	c.translations[lang][key] = translation
+}
+
+// Translate returns the translation of a message key for a language.
+func (c *MessageCatalog) Translate(lang, key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for {
+		if translation, ok := c.translations[lang][key]; ok && translation != "" {
+			return translation, true
+		}
+		i := strings.LastIndexAny(lang, "-_")
+		if i < 0 {
+			return "", false
+		}
+		lang = lang[:i]
+	}
+}
+
+// LoadJSON adds the translations of a JSON object mapping message keys to translations for a language.
+func (c *MessageCatalog) LoadJSON(lang string, r io.Reader) error {
+	var translations map[string]string
+	if err := json.NewDecoder(r).Decode(&translations); err != nil {
+		return WithCode(Wrapf(err, "failed to decode JSON catalog for %v", lang), CodeInvalidArgument)
+	}
+	for key, translation := range translations {
+		c.Add(lang, key, translation)
+	}
+	return nil
+}
+
+// LoadPO adds the translations of a gettext PO file for a language. Only singular messages without context are
+// supported, plural forms and messages with context are skipped. Empty translations are skipped as well, so they
+// fall back to the original message.
+func (c *MessageCatalog) LoadPO(lang string, r io.Reader) error {
+	var entry poEntry
+	var current *strings.Builder
+	flush := func() {
+		if key, translation, ok := entry.translation(); ok {
+			c.Add(lang, key, translation)
+		}
+		entry = poEntry{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for no := 1; scanner.Scan(); no++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value := line
+		if !strings.HasPrefix(line, `"`) {
+			var keyword string
+			keyword, value, _ = strings.Cut(line, " ")
+			switch {
+			case keyword == "msgctxt":
+				flush()
+				entry.ctxt = &strings.Builder{}
+				current = entry.ctxt
+			case keyword == "msgid":
+				if entry.id != nil {
+					flush()
+				}
+				entry.id = &strings.Builder{}
+				current = entry.id
+			case keyword == "msgstr":
+				entry.str = &strings.Builder{}
+				current = entry.str
+			case keyword == "msgid_plural" || strings.HasPrefix(keyword, "msgstr["):
+				entry.plural = true
+				current = &strings.Builder{}
+			default:
+				return Errorf("unknown keyword %q in PO catalog for %v at line %v", keyword, lang, no).
+					WithCode(CodeInvalidArgument)
+			}
+		} else if current == nil {
+			return Errorf("unexpected string in PO catalog for %v at line %v", lang, no).WithCode(CodeInvalidArgument)
+		}
+		str, err := strconv.Unquote(strings.TrimSpace(value))
+		if err != nil {
+			return WithCode(Wrapf(err, "invalid string in PO catalog for %v at line %v", lang, no), CodeInvalidArgument)
+		}
+		current.WriteString(str)
+	}
+	if err := scanner.Err(); err != nil {
+		return Wrapf(err, "failed to read PO catalog for %v", lang)
+	}
+	flush()
+	return nil
+}
+
+// poEntry is a single message of a PO file.
+type poEntry struct {
+	ctxt   *strings.Builder
+	id     *strings.Builder
+	str    *strings.Builder
+	plural bool
+}
+
+// translation returns the key and translation of the entry, if it is a supported, non-empty translation.
+func (e poEntry) translation() (string, string, bool) {
+	if e.ctxt != nil || e.plural || e.id == nil || e.str == nil || e.id.Len() == 0 || e.str.Len() == 0 {
+		return "", "", false
+	}
+	return e.id.String(), e.str.String(), true
+}
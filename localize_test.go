@@ -0,0 +1,208 @@
+package eris_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+const testPO = `
+# German translations
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+
+msgid "query failed"
+msgstr "Abfrage fehlgeschlagen"
+
+#, c-format
+msgid "table %q not found in schema %q"
+msgstr ""
+"Tabelle %[1]q nicht gefunden "
+"im Schema %[2]q"
+
+msgctxt "menu"
+msgid "connection lost"
+msgstr "Menü"
+
+msgid "%d row"
+msgid_plural "%d rows"
+msgstr[0] "%d Zeile"
+msgstr[1] "%d Zeilen"
+
+msgid "untranslated"
+msgstr ""
+`
+
+const testJSON = `{"TABLE_NOT_FOUND": "Tabelle %[1]q existiert nicht", "connection lost": "Verbindung verloren", "100%% done": "100%% fertig"}`
+
+var errLocalizedTable = eris.Define("TABLE_NOT_FOUND", eris.CodeNotFound, "table %q does not exist")
+
+func TestMessageCatalog(t *testing.T) {
+	catalog := eris.NewMessageCatalog()
+	if err := catalog.LoadPO("de", strings.NewReader(testPO)); err != nil {
+		t.Fatalf("expected no error got { %v }", err)
+	}
+	if err := catalog.LoadJSON("de-CH", strings.NewReader(testJSON)); err != nil {
+		t.Fatalf("expected no error got { %v }", err)
+	}
+
+	tests := map[string]struct {
+		lang        string
+		key         string
+		translation string
+		ok          bool
+	}{
+		"simple": {
+			lang:        "de",
+			key:         "query failed",
+			translation: "Abfrage fehlgeschlagen",
+			ok:          true,
+		},
+		"multi-line": {
+			lang:        "de",
+			key:         "table %q not found in schema %q",
+			translation: "Tabelle %[1]q nicht gefunden im Schema %[2]q",
+			ok:          true,
+		},
+		"context skipped": {
+			lang: "de",
+			key:  "connection lost",
+		},
+		"plural skipped": {
+			lang: "de",
+			key:  "%d row",
+		},
+		"empty skipped": {
+			lang: "de",
+			key:  "untranslated",
+		},
+		"header skipped": {
+			lang: "de",
+			key:  "",
+		},
+		"regional": {
+			lang:        "de-CH",
+			key:         "connection lost",
+			translation: "Verbindung verloren",
+			ok:          true,
+		},
+		"regional falls back to base language": {
+			lang:        "de-CH",
+			key:         "query failed",
+			translation: "Abfrage fehlgeschlagen",
+			ok:          true,
+		},
+		"unknown language": {
+			lang: "fr",
+			key:  "query failed",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			translation, ok := catalog.Translate(tc.lang, tc.key)
+			if translation != tc.translation || ok != tc.ok {
+				t.Errorf("%v: expected { %v, %v } got { %v, %v }", desc, tc.translation, tc.ok, translation, ok)
+			}
+		})
+	}
+}
+
+func TestMessageCatalogInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown keyword": "msgfoo \"bar\"",
+		"dangling string": "\"bar\"",
+		"unquoted":        "msgid bar",
+	}
+	for desc, po := range tests {
+		t.Run(desc, func(t *testing.T) {
+			err := eris.NewMessageCatalog().LoadPO("de", strings.NewReader(po))
+			if err == nil || eris.GetCode(err) != eris.CodeInvalidArgument {
+				t.Errorf("%v: expected invalid argument error got { %v }", desc, err)
+			}
+		})
+	}
+	if err := eris.NewMessageCatalog().LoadJSON("de", strings.NewReader("[")); err == nil {
+		t.Errorf("expected error for invalid JSON")
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	catalog := eris.NewMessageCatalog()
+	if err := catalog.LoadPO("de", strings.NewReader(testPO)); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.LoadJSON("de", strings.NewReader(testJSON)); err != nil {
+		t.Fatal(err)
+	}
+	eris.SetTranslator(catalog)
+	t.Cleanup(func() { eris.SetTranslator(nil) })
+
+	tests := map[string]struct {
+		err       error
+		lang      string
+		localized string
+		str       string
+	}{
+		"static messages": {
+			err:       eris.Wrap(eris.New("connection lost"), "query failed"),
+			lang:      "de",
+			localized: "Abfrage fehlgeschlagen: Verbindung verloren",
+			str:       "code(internal) Abfrage fehlgeschlagen: code(unknown) Verbindung verloren",
+		},
+		"format with reordered args": {
+			err:       eris.Errorf("table %q not found in schema %q", "users", "public"),
+			lang:      "de",
+			localized: `Tabelle "users" nicht gefunden im Schema "public"`,
+			str:       `code(unknown) Tabelle "users" nicht gefunden im Schema "public"`,
+		},
+		"definition ID": {
+			err:       errLocalizedTable.New("users"),
+			lang:      "de",
+			localized: `Tabelle "users" existiert nicht`,
+			str:       `code(not found) Tabelle "users" existiert nicht`,
+		},
+		"sensitive args stay redacted": {
+			err:       eris.Errorf("table %q not found in schema %q", eris.Sensitive("users"), "public"),
+			lang:      "de",
+			localized: `Tabelle "[redacted]" nicht gefunden im Schema "public"`,
+			str:       `code(unknown) Tabelle "[redacted]" nicht gefunden im Schema "public"`,
+		},
+		"format without args": {
+			err:       eris.Errorf("100%% done"),
+			lang:      "de",
+			localized: "100% fertig",
+			str:       "code(unknown) 100% fertig",
+		},
+		"joined": {
+			err:       eris.Wrap(errors.Join(eris.New("connection lost"), errors.New("external")), "query failed"),
+			lang:      "de",
+			localized: "Abfrage fehlgeschlagen: Verbindung verloren\nexternal",
+			str:       "code(internal) Abfrage fehlgeschlagen\n0>\tcode(unknown) Verbindung verloren\n1>\texternal",
+		},
+		"fallback": {
+			err:       eris.Wrap(errors.New("external"), "no translation"),
+			lang:      "de",
+			localized: "no translation: external",
+			str:       "code(internal) no translation: external",
+		},
+		"unknown language": {
+			err:       eris.New("query failed"),
+			lang:      "fr",
+			localized: "query failed",
+			str:       "code(unknown) query failed",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if localized := eris.Localize(tc.err, tc.lang); localized != tc.localized {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.localized, localized)
+			}
+			if str := eris.ToStringLocalized(tc.err, tc.lang, false); str != tc.str {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.str, str)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package eris
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+var (
+	// localModules caches the result of localModule by directory.
+	localModules sync.Map
+
+	mainModuleOnce sync.Once
+	mainModule     string
+)
+
+// Module returns the path of the application module containing the frame's package, or an empty string if the
+// package belongs to a dependency or the standard library.
+//
+// The module is read from the go.mod file found in the directory of the frame's file or one of its parents, so it
+// is known in test binaries and for modules that are checked out locally, e.g. via a replace directive. go.mod
+// files in the module cache belong to dependencies and are ignored. If there is no go.mod file, e.g. because the
+// binary runs on another machine or was built with -trimpath, the main module of the build info is used instead.
+func (f *StackFrame) Module() string {
+	if f.Package == "" {
+		return ""
+	}
+	if module := localModule(filepath.Dir(f.File)); module != "" && hasPathPrefix(f.Package, module) {
+		return module
+	}
+	if module := mainModulePath(); module != "" && hasPathPrefix(f.Package, module) {
+		return module
+	}
+	return ""
+}
+
+// localModule returns the path of the module declared by the go.mod file in dir or its closest parent containing
+// one. It returns an empty string if there is no go.mod file or if it is part of the module cache.
+func localModule(dir string) string {
+	if !filepath.IsAbs(dir) {
+		return ""
+	}
+	if module, ok := localModules.Load(dir); ok {
+		return module.(string)
+	}
+	var module string
+	for d := dir; ; d = filepath.Dir(d) {
+		if data, err := os.ReadFile(filepath.Join(d, "go.mod")); err == nil {
+			// directories in the module cache carry the module version, e.g. eris@v1.2.3
+			if !strings.Contains(d, "@") {
+				module = modulePath(data)
+			}
+			break
+		}
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	localModules.Store(dir, module)
+	return module
+}
+
+// modulePath returns the module path declared by the contents of a go.mod file.
+func modulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+	return ""
+}
+
+// mainModulePath returns the path of the main module of the build info, or an empty string if it is unknown. Note
+// that test binaries built before Go 1.24 don't record their main module.
+func mainModulePath() string {
+	mainModuleOnce.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			mainModule = info.Main.Path
+		}
+	})
+	return mainModule
+}
+
+// hasPathPrefix returns true if the slash-separated path equals prefix or is contained in it.
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
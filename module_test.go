@@ -0,0 +1,51 @@
+package eris_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestFrameModule(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	tests := map[string]struct {
+		frame    eris.StackFrame
+		module   string
+		relative string
+	}{
+		"module root": {
+			frame:    eris.StackFrame{File: file, Package: "github.com/risingwavelabs/eris"},
+			module:   "github.com/risingwavelabs/eris",
+			relative: "module_test.go",
+		},
+		"module package": {
+			frame:    eris.StackFrame{File: filepath.Join(filepath.Dir(file), "eristest", "golden.go"), Package: "github.com/risingwavelabs/eris/eristest"},
+			module:   "github.com/risingwavelabs/eris",
+			relative: "eristest/golden.go",
+		},
+		"package outside module": {
+			frame:    eris.StackFrame{File: file, Package: "github.com/acme/db"},
+			relative: "github.com/acme/db/module_test.go",
+		},
+		"standard library": {
+			frame:    eris.StackFrame{File: "/usr/local/go/src/runtime/proc.go", Package: "runtime"},
+			relative: "runtime/proc.go",
+		},
+		"without package": {
+			frame:    eris.StackFrame{File: "/tmp/main.go"},
+			relative: "/tmp/main.go",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if module := tc.frame.Module(); module != tc.module {
+				t.Errorf("%v: expected module { %v } got { %v }", desc, tc.module, module)
+			}
+			if relative := tc.frame.RelativePath(); relative != tc.relative {
+				t.Errorf("%v: expected relative path { %v } got { %v }", desc, tc.relative, relative)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package eris
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Multi accumulates multiple errors, e.g. the errors of a batch operation, into a single error.
+// It is safe for concurrent use. The zero value is an empty accumulator that keeps all errors.
+type Multi struct {
+	mu      sync.Mutex
+	max     int     // maximum number of kept errors, 0 means unlimited
+	errs    []error // kept errors
+	omitted int     // number of errors exceeding max
+	code    Code    // most severe effective code of all appended errors
+	coded   bool    // flag indicating whether any appended error has an explicit code
+}
+
+// NewMulti returns an accumulator that keeps at most max errors. Errors exceeding max are only counted and
+// summarized as "and N more". A max of 0 keeps all errors.
+func NewMulti(max int) *Multi {
+	return &Multi{max: max}
+}
+
+// Append adds errors to the accumulator. Nil errors are ignored.
+func (m *Multi) Append(errs ...error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if code := EffectiveCode(err, OutermostExplicit); m.count() == 0 || code.MoreSevere(m.code) {
+			m.code = code
+		}
+		if _, ok := explicitCode(err); ok {
+			m.coded = true
+		}
+		if m.max > 0 && len(m.errs) >= m.max {
+			m.omitted++
+		} else {
+			m.errs = append(m.errs, err)
+		}
+	}
+}
+
+// Len returns the number of appended errors, including the ones exceeding the cap.
+func (m *Multi) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count()
+}
+
+// count returns the number of appended errors. The caller must hold the lock.
+func (m *Multi) count() int {
+	return len(m.errs) + m.omitted
+}
+
+// ErrorOrNil returns nil if no errors were appended. Otherwise it returns a root error that joins all kept
+// errors, so each of them keeps its own code, KVs and stack trace and is reachable via Is and As.
+//
+// The code of the returned error is the most severe effective code of all appended errors, which is the common
+// code if all errors agree on it. Errors without explicit code take part with their default code, e.g. 'unknown'
+// for external errors and 'internal' for wrapped ones, see EffectiveCode.
+func (m *Multi) ErrorOrNil() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.count() == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%d errors occurred", len(m.errs))
+	if len(m.errs) == 1 {
+		msg = "1 error occurred"
+	}
+	if m.omitted > 0 {
+		msg += fmt.Sprintf(" (and %d more)", m.omitted)
+	}
+
+	errs := make([]error, len(m.errs))
+	copy(errs, m.errs)
+	stack := callers(3)
+	root := &rootError{
+		msg:   msg,
+		ext:   &joinError{errs: errs},
+		stack: stack,
+		code:  m.code,
+		coded: m.coded,
+	}
+	return root
+}
@@ -0,0 +1,163 @@
+package eris_test
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestMulti(t *testing.T) {
+	tests := map[string]struct {
+		max    int
+		errs   []error
+		len    int
+		msg    string
+		code   eris.Code
+		isNil  bool
+		output string
+	}{
+		"no errors": {
+			errs:  []error{nil, nil},
+			isNil: true,
+		},
+		"single error": {
+			errs: []error{eris.New("row 1").WithCode(eris.CodeInvalidArgument)},
+			len:  1,
+			msg:  "1 error occurred",
+			code: eris.CodeInvalidArgument,
+		},
+		"codes agree": {
+			errs: []error{
+				eris.New("row 1").WithCode(eris.CodeInvalidArgument),
+				nil,
+				eris.Wrap(eris.New("row 2").WithCode(eris.CodeInvalidArgument), "wrap"),
+			},
+			len:  2,
+			msg:  "2 errors occurred",
+			code: eris.CodeInvalidArgument,
+		},
+		"most severe code": {
+			errs: []error{
+				eris.New("row 1").WithCode(eris.CodeInvalidArgument),
+				eris.New("row 2").WithCode(eris.CodeDataLoss),
+				eris.New("row 3").WithCode(eris.CodeNotFound),
+			},
+			len:  3,
+			msg:  "3 errors occurred",
+			code: eris.CodeDataLoss,
+		},
+		"default codes take part": {
+			errs: []error{
+				errors.New("row 1"),
+				eris.Wrap(eris.New("row 2").WithCode(eris.CodeInvalidArgument), "wrap"),
+				eris.Wrap(errors.New("row 3"), "wrap"),
+			},
+			len:  3,
+			msg:  "3 errors occurred",
+			code: eris.CodeInternal,
+		},
+		"external errors count as unknown": {
+			errs: []error{
+				errors.New("row 1"),
+				eris.New("row 2").WithCode(eris.CodeInvalidArgument),
+			},
+			len:  2,
+			msg:  "2 errors occurred",
+			code: eris.CodeUnknown,
+		},
+		"no explicit codes": {
+			errs: []error{errors.New("row 1"), eris.New("row 2")},
+			len:  2,
+			msg:  "2 errors occurred",
+			code: eris.CodeUnknown,
+		},
+		"capped": {
+			max: 2,
+			errs: []error{
+				eris.New("row 1").WithCode(eris.CodeInvalidArgument),
+				eris.New("row 2").WithCode(eris.CodeInvalidArgument),
+				eris.New("row 3").WithCode(eris.CodeInvalidArgument),
+				eris.New("row 4").WithCode(eris.CodeUnavailable),
+			},
+			len:    4,
+			msg:    "2 errors occurred (and 2 more)",
+			code:   eris.CodeUnavailable,
+			output: "code(unavailable) 2 errors occurred (and 2 more)\n0>\tcode(invalid argument) row 1\n1>\tcode(invalid argument) row 2",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			m := eris.NewMulti(tc.max)
+			m.Append(tc.errs...)
+			if m.Len() != tc.len {
+				t.Errorf("%v: expected length { %v } got { %v }", desc, tc.len, m.Len())
+			}
+			err := m.ErrorOrNil()
+			if tc.isNil {
+				if err != nil {
+					t.Errorf("%v: expected nil error got { %v }", desc, err)
+				}
+				return
+			}
+			if msg := eris.Unpack(err).ErrRoot.Msg; msg != tc.msg {
+				t.Errorf("%v: expected message { %v } got { %v }", desc, tc.msg, msg)
+			}
+			if code := eris.GetCode(err); code != tc.code {
+				t.Errorf("%v: expected code { %v } got { %v }", desc, tc.code, code)
+			}
+			if tc.output != "" && err.Error() != tc.output {
+				t.Errorf("%v: expected\n%v\ngot\n%v", desc, tc.output, err.Error())
+			}
+		})
+	}
+}
+
+func TestMultiChildren(t *testing.T) {
+	row := eris.New("row 2").WithCode(eris.CodeNotFound).WithProperty("row", 2)
+	var m eris.Multi
+	m.Append(errors.New("row 1"), row)
+	err := m.ErrorOrNil()
+
+	if !eris.Is(err, row) {
+		t.Errorf("expected children to be reachable via Is")
+	}
+	if v, ok := eris.FindProperty[int](err, "row"); !ok || v != 2 {
+		t.Errorf("expected { %v } got { %v, %v }", 2, v, ok)
+	}
+
+	errJSON := eris.ToJSON(err, true)
+	externals, ok := errJSON["externals"].([]map[string]any)
+	if !ok || len(externals) != 2 {
+		t.Fatalf("expected two externals got { %v }", errJSON)
+	}
+	if externals[0]["external"] != "row 1" {
+		t.Errorf("expected { %v } got { %v }", "row 1", externals[0]["external"])
+	}
+	root, ok := externals[1]["root"].(map[string]any)
+	if !ok || root["code"] != "not found" || root["stack"] == nil {
+		b, _ := json.Marshal(externals[1])
+		t.Errorf("expected child with own code and trace got { %v }", string(b))
+	}
+}
+
+func TestMultiConcurrent(t *testing.T) {
+	m := eris.NewMulti(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Append(eris.New("concurrent error"))
+		}()
+	}
+	wg.Wait()
+	if m.Len() != 100 {
+		t.Errorf("expected length { %v } got { %v }", 100, m.Len())
+	}
+	if msg := eris.Unpack(m.ErrorOrNil()).ErrRoot.Msg; msg != "10 errors occurred (and 90 more)" {
+		t.Errorf("expected { %v } got { %v }", "10 errors occurred (and 90 more)", msg)
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/risingwavelabs/eris/oteleris
+
+go 1.21
+
+require (
+	github.com/risingwavelabs/eris v0.1.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	google.golang.org/grpc v1.53.0 // indirect
+)
@@ -0,0 +1,143 @@
+// Package oteleris records eris errors on OpenTelemetry spans and attaches the current span to errors.
+//
+//	span := trace.SpanFromContext(ctx)
+//	oteleris.RecordError(span, err)
+//
+// Register installs a context extractor, so errors created with a context, e.g. via eris.WrapCtx or
+// eris.FromContext, carry the trace ID and span ID of the span stored in the context:
+//
+//	func main() {
+//		oteleris.Register()
+//		...
+//	}
+package oteleris
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/risingwavelabs/eris"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// TraceIDKey is the property holding the trace ID of the span an error was created in.
+	TraceIDKey = eris.NewKey[string]("trace_id")
+	// SpanIDKey is the property holding the ID of the span an error was created in.
+	SpanIDKey = eris.NewKey[string]("span_id")
+)
+
+var registerOnce sync.Once
+
+// Register registers SpanFields as context extractor, see eris.RegisterContextExtractor. It is meant to be called
+// once during initialization, further calls have no effect.
+func Register() {
+	registerOnce.Do(func() {
+		eris.RegisterContextExtractor(SpanFields)
+	})
+}
+
+// SpanFields returns the trace ID and span ID of the span stored in ctx as fields. It returns nil if ctx doesn't
+// hold a valid span context.
+func SpanFields(ctx context.Context) []eris.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []eris.Field{
+		TraceIDKey.Field(sc.TraceID().String()),
+		SpanIDKey.Field(sc.SpanID().String()),
+	}
+}
+
+// Options configures how errors are recorded on spans.
+type Options struct {
+	KVPrefix string // Prefix of the attribute keys of KVs, e.g. "eris." for "eris.table".
+}
+
+// DefaultOptions are the options used by RecordError.
+var DefaultOptions = Options{
+	KVPrefix: "eris.",
+}
+
+// RecordError records an error on a span with the default options, see Options.RecordError.
+func RecordError(span trace.Span, err error, opts ...trace.EventOption) {
+	DefaultOptions.RecordError(span, err, opts...)
+}
+
+// RecordError records an error on a span. It does nothing for a nil error or a span that isn't recording.
+//
+// The "error.type" attribute is set to the effective code of the error, and the span status is set to error
+// unless the code says that the error is caused by the client or a cancellation, i.e. the default severity of the
+// code is below warning, see eris.Code.Severity. Severities set on the error don't affect the status. An
+// "exception" event is added with the ID of the definition the error was created from or its code as
+// "exception.type", the error message as "exception.message" and the error chain with stack traces as
+// "exception.stacktrace". The KVs of the chain are added to the event with the configured prefix. Sensitive
+// values are masked.
+func (o Options) RecordError(span trace.Span, err error, opts ...trace.EventOption) {
+	if err == nil || span == nil || !span.IsRecording() {
+		return
+	}
+	code := eris.EffectiveCode(err, eris.OutermostExplicit)
+	if code.Severity() >= eris.SeverityWarning {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.SetAttributes(semconv.ErrorTypeKey.String(code.String()))
+
+	exceptionType := code.String()
+	if id := eris.GetID(err); id != "" {
+		exceptionType = id
+	}
+	attrs := []attribute.KeyValue{
+		semconv.ExceptionType(exceptionType),
+		semconv.ExceptionMessage(err.Error()),
+		semconv.ExceptionStacktrace(eris.ToCustomString(err, eris.NewDefaultStringFormat(eris.FormatOptions{
+			WithTrace:    true,
+			WithExternal: true,
+		}))),
+	}
+	attrs = append(attrs, o.kvAttributes(eris.Unpack(err))...)
+	span.AddEvent(semconv.ExceptionEventName, append(opts, trace.WithAttributes(attrs...))...)
+}
+
+// kvAttributes returns the KVs of the chain as attributes sorted by key, see eris.UnpackedError.KVs.
+func (o Options) kvAttributes(upErr eris.UnpackedError) []attribute.KeyValue {
+	kvs := upErr.KVs()
+	keys := make([]string, 0, len(kvs))
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]attribute.KeyValue, 0, len(kvs))
+	for _, k := range keys {
+		attrs = append(attrs, attributeOf(attribute.Key(o.KVPrefix+k), kvs[k]))
+	}
+	return attrs
+}
+
+// attributeOf converts a KV value into an attribute. Values of unsupported types are formatted as strings.
+func attributeOf(key attribute.Key, v any) attribute.KeyValue {
+	switch val := v.(type) {
+	case string:
+		return key.String(val)
+	case bool:
+		return key.Bool(val)
+	case int:
+		return key.Int(val)
+	case int64:
+		return key.Int64(val)
+	case float64:
+		return key.Float64(val)
+	case []string:
+		return key.StringSlice(val)
+	case fmt.Stringer:
+		return key.String(val.String())
+	default:
+		return key.String(fmt.Sprint(val))
+	}
+}
@@ -0,0 +1,167 @@
+package oteleris_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+	"github.com/risingwavelabs/eris/oteleris"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTracer returns a tracer whose spans are recorded by the returned exporter.
+func newTracer(t *testing.T) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider, exporter
+}
+
+func TestRecordError(t *testing.T) {
+	provider, exporter := newTracer(t)
+	_, span := provider.Tracer("test").Start(context.Background(), "load")
+
+	err := eris.Wrap(eris.Wrapf(io.ErrUnexpectedEOF, "failed to read %v", "users"), "failed to load")
+	err = eris.WithCode(eris.WithProperty(eris.WithProperty(err, "rows", 42), "password", eris.Sensitive("secret")), eris.CodeDataLoss)
+	oteleris.RecordError(span, err)
+	oteleris.RecordError(span, nil)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected { 1 } span got { %v }", len(spans))
+	}
+	stub := spans[0]
+	if stub.Status.Code != codes.Error || stub.Status.Description != err.Error() {
+		t.Errorf("expected error status { %v } got { %v: %v }", err.Error(), stub.Status.Code, stub.Status.Description)
+	}
+	if !hasAttribute(stub.Attributes, attribute.String("error.type", "data loss")) {
+		t.Errorf("expected error type attribute got { %v }", stub.Attributes)
+	}
+	if len(stub.Events) != 1 || stub.Events[0].Name != "exception" {
+		t.Fatalf("expected { 1 } exception event got { %v }", stub.Events)
+	}
+
+	attrs := stub.Events[0].Attributes
+	expected := []attribute.KeyValue{
+		attribute.String("exception.type", "data loss"),
+		attribute.String("exception.message", err.Error()),
+		attribute.String("eris.password", "[redacted]"),
+		attribute.Int("eris.rows", 42),
+	}
+	for _, attr := range expected {
+		if !hasAttribute(attrs, attr) {
+			t.Errorf("expected attribute { %v=%v } got { %v }", attr.Key, attr.Value.Emit(), attrs)
+		}
+	}
+	var stacktrace string
+	for _, attr := range attrs {
+		if attr.Key == "exception.stacktrace" {
+			stacktrace = attr.Value.AsString()
+		}
+	}
+	for _, s := range []string{"failed to load", "failed to read users", "oteleris_test.TestRecordError:", "unexpected EOF"} {
+		if !strings.Contains(stacktrace, s) {
+			t.Errorf("expected stack trace to contain { %v } got { %v }", s, stacktrace)
+		}
+	}
+}
+
+func TestRecordErrorSeverity(t *testing.T) {
+	provider, exporter := newTracer(t)
+	_, span := provider.Tracer("test").Start(context.Background(), "compact")
+
+	err := eris.New("checksum mismatch").WithCode(eris.CodeDataLoss).WithSeverity(eris.SeverityInfo)
+	oteleris.RecordError(span, err)
+	span.End()
+
+	if status := exporter.GetSpans()[0].Status; status.Code != codes.Error {
+		t.Errorf("expected error status regardless of the severity got { %v }", status)
+	}
+}
+
+func TestRecordErrorOptions(t *testing.T) {
+	provider, exporter := newTracer(t)
+	_, span := provider.Tracer("test").Start(context.Background(), "query")
+
+	def := eris.Define("storage.table_missing", eris.CodeNotFound, "table %v is missing")
+	err := eris.WithProperty(def.New("users"), "tables", []string{"users", "orders"})
+	oteleris.Options{KVPrefix: "app.error."}.RecordError(span, err)
+	span.End()
+
+	if status := exporter.GetSpans()[0].Status; status.Code != codes.Unset {
+		t.Errorf("expected no error status for a client error got { %v }", status)
+	}
+	attrs := exporter.GetSpans()[0].Events[0].Attributes
+	for _, attr := range []attribute.KeyValue{
+		attribute.String("exception.type", "storage.table_missing"),
+		attribute.StringSlice("app.error.tables", []string{"users", "orders"}),
+	} {
+		if !hasAttribute(attrs, attr) {
+			t.Errorf("expected attribute { %v=%v } got { %v }", attr.Key, attr.Value.Emit(), attrs)
+		}
+	}
+}
+
+func TestRecordErrorNotRecording(t *testing.T) {
+	provider, exporter := newTracer(t)
+	_, span := provider.Tracer("test").Start(context.Background(), "ended")
+	span.End()
+	oteleris.RecordError(span, errors.New("too late"))
+	if events := exporter.GetSpans()[0].Events; len(events) != 0 {
+		t.Errorf("expected no events on an ended span got { %v }", events)
+	}
+}
+
+func TestSpanFields(t *testing.T) {
+	oteleris.Register()
+	oteleris.Register()
+	provider, _ := newTracer(t)
+	ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	tests := map[string]struct {
+		err     error
+		traceID string
+		spanID  string
+	}{
+		"wrap with context": {
+			err:     eris.WrapCtx(ctx, io.EOF, "read failed"),
+			traceID: span.SpanContext().TraceID().String(),
+			spanID:  span.SpanContext().SpanID().String(),
+		},
+		"builder": {
+			err:     eris.FromContext(ctx).New("failed"),
+			traceID: span.SpanContext().TraceID().String(),
+			spanID:  span.SpanContext().SpanID().String(),
+		},
+		"without span": {
+			err: eris.WrapCtx(context.Background(), io.EOF, "read failed"),
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			traceID, _ := oteleris.TraceIDKey.Find(tc.err)
+			spanID, _ := oteleris.SpanIDKey.Find(tc.err)
+			if traceID != tc.traceID || spanID != tc.spanID {
+				t.Errorf("%v: expected IDs { %v %v } got { %v %v }", desc, tc.traceID, tc.spanID, traceID, spanID)
+			}
+		})
+	}
+}
+
+// hasAttribute returns true if attrs contain the attribute.
+func hasAttribute(attrs []attribute.KeyValue, attr attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a.Key == attr.Key && a.Value.Emit() == attr.Value.Emit() {
+			return true
+		}
+	}
+	return false
+}
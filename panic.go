@@ -0,0 +1,96 @@
+package eris
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Recover converts a panic into an error and stores it in errp. It must be deferred directly:
+//
+//	func process() (err error) {
+//		defer eris.Recover(&err)
+//		...
+//	}
+//
+// The error has code 'internal' and its stack trace starts at the panic site. Recover does nothing if the
+// goroutine is not panicking.
+func Recover(errp *error) {
+	r := recover()
+	if r == nil || errp == nil {
+		return
+	}
+	*errp = newPanicError(r)
+}
+
+// FromPanic converts a recovered panic value into an error with code 'internal'. It returns nil for a nil value.
+//
+// If the value is an error, it becomes the external cause, so e.g. a `runtime.Error` stays reachable via As.
+// Otherwise the value is attached as property "panic". When called from the deferred function that recovered
+// the panic, the stack trace starts at the panic site instead of the recover site.
+func FromPanic(v any) error {
+	if v == nil {
+		return nil
+	}
+	return newPanicError(v)
+}
+
+// Go calls f in a new goroutine and sends its result to the returned channel, which is closed afterwards.
+// A panic in f is converted into an error, see FromPanic.
+func Go(f func() error) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		ch <- runRecovered(f)
+	}()
+	return ch
+}
+
+// newPanicError converts a recovered panic value into a root error with code 'internal'. It must be called
+// by Recover, FromPanic or the deferred function that recovered the panic.
+func newPanicError(v any) error {
+	stack := panicStack()
+	root := &rootError{
+		stack: stack,
+		code:  CodeInternal,
+		coded: true,
+	}
+	if err, ok := v.(error); ok {
+		root.msg = "panic"
+		root.ext = err
+	} else {
+		root.msg = fmt.Sprintf("panic: %v", v)
+		root.kvs = map[string]any{"panic": v}
+	}
+	return root
+}
+
+// panicStack returns the stack trace of the panicking goroutine starting at the panic site. If the goroutine is
+// not panicking, the trace starts at the caller of the function calling panicStack.
+func panicStack() *stack {
+	// callers(3) skips runtime.Callers, stack.callers and this method
+	st := callers(3)
+	start := -1
+	for i, pc := range *st {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		// callers(5) additionally skips newPanicError and its caller
+		return callers(5)
+	}
+	if start >= len(*st) {
+		return st
+	}
+	// skip runtime helpers that raise panics for runtime errors, e.g. runtime.sigpanic or runtime.panicIndex
+	for start < len(*st)-1 {
+		fn := runtime.FuncForPC((*st)[start] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+			break
+		}
+		start++
+	}
+	trimmed := (*st)[start:]
+	return &trimmed
+}
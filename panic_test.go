@@ -0,0 +1,142 @@
+package eris_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func nilDeref() (err error) {
+	defer eris.Recover(&err)
+	var p *struct{ n int }
+	p.n = 1 // panics, nil pointer dereference
+	return nil
+}
+
+func panicValue() (err error) {
+	defer eris.Recover(&err)
+	panic("boom")
+}
+
+func noPanic() (err error) {
+	defer eris.Recover(&err)
+	return eris.New("regular error")
+}
+
+func TestRecover(t *testing.T) {
+	tests := map[string]struct {
+		fn      func() error
+		msg     string
+		code    eris.Code
+		frame   string
+		runtime bool
+		kv      any
+	}{
+		"runtime error": {
+			fn:      nilDeref,
+			msg:     "panic",
+			code:    eris.CodeInternal,
+			frame:   "eris_test.nilDeref",
+			runtime: true,
+		},
+		"panic value": {
+			fn:    panicValue,
+			msg:   "panic: boom",
+			code:  eris.CodeInternal,
+			frame: "eris_test.panicValue",
+			kv:    "boom",
+		},
+		"no panic": {
+			fn:    noPanic,
+			msg:   "regular error",
+			code:  eris.CodeUnknown,
+			frame: "eris_test.noPanic",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			err := tc.fn()
+			up := eris.Unpack(err)
+			if up.ErrRoot.Msg != tc.msg {
+				t.Errorf("%v: expected message { %v } got { %v }", desc, tc.msg, up.ErrRoot.Msg)
+			}
+			if code := eris.GetCode(err); code != tc.code {
+				t.Errorf("%v: expected code { %v } got { %v }", desc, tc.code, code)
+			}
+			if len(up.ErrRoot.Stack) == 0 || up.ErrRoot.Stack[0].Name != tc.frame {
+				t.Errorf("%v: expected stack to start at { %v } got { %v }", desc, tc.frame, up.ErrRoot.Stack)
+			}
+			var rerr runtime.Error
+			if errors.As(err, &rerr) != tc.runtime {
+				t.Errorf("%v: expected runtime error reachable { %v }", desc, tc.runtime)
+			}
+			if v := eris.GetKVs(err)["panic"]; v != tc.kv {
+				t.Errorf("%v: expected property { %v } got { %v }", desc, tc.kv, v)
+			}
+		})
+	}
+}
+
+func TestRecoverNilTarget(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("expected no panic got { %v }", r)
+		}
+	}()
+	func() {
+		defer eris.Recover(nil)
+		panic("ignored")
+	}()
+}
+
+func TestFromPanic(t *testing.T) {
+	if err := eris.FromPanic(nil); err != nil {
+		t.Errorf("expected nil error got { %v }", err)
+	}
+
+	cause := errors.New("external cause")
+	var err error
+	func() {
+		defer func() {
+			err = eris.FromPanic(recover())
+		}()
+		panic(cause)
+	}()
+	if !errors.Is(err, cause) {
+		t.Errorf("expected cause to be reachable via Is")
+	}
+	if !eris.HasExplicitCode(err) || eris.GetCode(err) != eris.CodeInternal {
+		t.Errorf("expected explicit code { %v } got { %v }", eris.CodeInternal, eris.GetCode(err))
+	}
+	stack := eris.Unpack(err).ErrRoot.Stack
+	if len(stack) == 0 || stack[0].Name != "eris_test.TestFromPanic.func1" {
+		t.Errorf("expected stack to start at the panic site, got { %v }", stack)
+	}
+}
+
+func TestGo(t *testing.T) {
+	if err := <-eris.Go(func() error { return nil }); err != nil {
+		t.Errorf("expected nil error got { %v }", err)
+	}
+
+	want := eris.New("task failed")
+	if err := <-eris.Go(func() error { return want }); err != want {
+		t.Errorf("expected { %v } got { %v }", want, err)
+	}
+
+	ch := eris.Go(func() error {
+		var m map[string]int
+		m["key"] = 1 // panics, assignment to nil map
+		return nil
+	})
+	err := <-ch
+	var rerr runtime.Error
+	if !errors.As(err, &rerr) {
+		t.Errorf("expected runtime error got { %v }", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Errorf("expected channel to be closed")
+	}
+}
@@ -0,0 +1,118 @@
+// Package prometheuseris counts eris errors with Prometheus.
+//
+//	collector := prometheuseris.NewCollector(prometheuseris.Options{})
+//	defer collector.Close()
+//	prometheus.MustRegister(collector)
+//
+// The collector registers an eris.OnCreate hook and counts every root error, i.e. every error created via eris.New
+// or eris.Errorf and every external error wrapped via eris.Wrap, in the counter "eris_errors_total" with the labels
+// "op", "code", "domain" and "function", where "function" is the function that created the root error. Wrapping an
+// eris error again doesn't count it twice. Errors are counted once, with the code they end up with: the events of
+// new errors are kept until the next scrape, and events with eris.OpWith update the code of the pending error, e.g.
+// for eris.New("failed").WithCode(eris.CodeNotFound).
+package prometheuseris
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/risingwavelabs/eris"
+)
+
+// maxPending is the number of errors kept until the next scrape. If there are more, the pending errors are
+// counted right away.
+const maxPending = 1024
+
+// Options configures a collector.
+type Options struct {
+	Namespace string // Namespace of the metric, e.g. "app" for "app_eris_errors_total".
+}
+
+// pendingError is a created error that isn't counted yet.
+type pendingError struct {
+	op       string
+	code     string
+	domain   string
+	function string
+}
+
+// Collector is a Prometheus collector counting created root errors. It is safe for concurrent use.
+type Collector struct {
+	errors *prometheus.CounterVec
+	remove func()
+
+	mu      sync.Mutex
+	pending []pendingError
+	index   map[error]int // index of the pending errors by error
+}
+
+// NewCollector returns a collector that counts errors until it is closed. It still has to be registered with a
+// Prometheus registry.
+func NewCollector(opts Options) *Collector {
+	errors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: opts.Namespace,
+		Subsystem: "eris",
+		Name:      "errors_total",
+		Help:      "Number of root errors created, by operation, code, domain and origin function.",
+	}, []string{"op", "code", "domain", "function"})
+	c := &Collector{errors: errors, index: make(map[error]int)}
+	c.remove = eris.OnCreate(c.observe)
+	return c
+}
+
+// observe adds the error of a creation event of a root error to the pending errors, or updates the pending error
+// of an event with eris.OpWith. Wraps of eris errors are ignored.
+func (c *Collector) observe(event eris.ErrorEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if event.Op == eris.OpWrap && !event.Root {
+		return
+	}
+	if event.Op == eris.OpWith {
+		if i, ok := c.index[event.Err]; ok {
+			c.pending[i].code = event.Code.String()
+			c.pending[i].domain = eris.Domain(event.Err)
+		}
+		return
+	}
+	if len(c.pending) >= maxPending {
+		c.flush()
+	}
+	c.index[event.Err] = len(c.pending)
+	c.pending = append(c.pending, pendingError{
+		op:       event.Op.String(),
+		code:     event.Code.String(),
+		domain:   eris.Domain(event.Err),
+		function: event.Origin.Name,
+	})
+}
+
+// flush counts the pending errors. The caller must hold c.mu.
+func (c *Collector) flush() {
+	for _, p := range c.pending {
+		c.errors.WithLabelValues(p.op, p.code, p.domain, p.function).Inc()
+	}
+	c.pending = nil
+	clear(c.index)
+}
+
+// Close stops counting errors. The counted values are kept.
+func (c *Collector) Close() {
+	c.remove()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flush()
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.errors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	c.flush()
+	c.mu.Unlock()
+	c.errors.Collect(ch)
+}
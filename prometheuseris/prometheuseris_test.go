@@ -0,0 +1,50 @@
+package prometheuseris_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/risingwavelabs/eris"
+	"github.com/risingwavelabs/eris/prometheuseris"
+)
+
+func readTable() error {
+	return eris.Wrap(io.EOF, "failed to read table")
+}
+
+func findUser() error {
+	return eris.New("user not found").WithCode(eris.CodeNotFound)
+}
+
+func loadTable() error {
+	return eris.Wrap(readTable(), "failed to load table")
+}
+
+func TestCollector(t *testing.T) {
+	collector := prometheuseris.NewCollector(prometheuseris.Options{Namespace: "test"})
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	for i := 0; i < 3; i++ {
+		_ = readTable()
+	}
+	_ = eris.WithCode(readTable(), eris.CodeDataLoss)
+	_ = findUser()
+	_ = eris.Wrap(loadTable(), "failed to start")
+	collector.Close()
+	_ = readTable()
+
+	expected := `
+# HELP test_eris_errors_total Number of root errors created, by operation, code, domain and origin function.
+# TYPE test_eris_errors_total counter
+test_eris_errors_total{code="data loss",domain="prometheuseris",function="prometheuseris_test.readTable",op="wrap"} 1
+test_eris_errors_total{code="internal",domain="prometheuseris",function="prometheuseris_test.readTable",op="wrap"} 4
+test_eris_errors_total{code="not found",domain="prometheuseris",function="prometheuseris_test.findUser",op="new"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,60 @@
+package eris
+
+// defaultPublicMessages are the generic public messages per code, used if no error in the chain has a public
+// message. They don't reveal anything about the internals of the service.
+var defaultPublicMessages = map[Code]string{
+	CodeCanceled:           "The operation was canceled.",
+	CodeUnknown:            "An unknown error occurred.",
+	CodeInvalidArgument:    "The request is invalid.",
+	CodeDeadlineExceeded:   "The operation timed out.",
+	CodeNotFound:           "The requested resource was not found.",
+	CodeAlreadyExists:      "The resource already exists.",
+	CodePermissionDenied:   "Permission denied.",
+	CodeResourceExhausted:  "Too many requests, please try again later.",
+	CodeFailedPrecondition: "The operation cannot be performed in the current state.",
+	CodeAborted:            "The operation was aborted, please try again.",
+	CodeOutOfRange:         "The request is out of range.",
+	CodeUnimplemented:      "The operation is not supported.",
+	CodeInternal:           "An internal error occurred.",
+	CodeUnavailable:        "The service is currently unavailable, please try again later.",
+	CodeDataLoss:           "An internal error occurred.",
+	CodeUnauthenticated:    "Authentication is required.",
+}
+
+// PublicMessages returns a Field of PublicMessageType.
+func PublicMessages(msg string) Field {
+	return Field{
+		Type:  PublicMessageType,
+		Value: msg,
+	}
+}
+
+// WithPublicMessage attaches a message to an error that is safe to show to clients, see PublicMessage.
+func WithPublicMessage(err error, msg string) error {
+	return With(err, PublicMessages(msg))
+}
+
+// PublicMessage returns the message of an error that is safe to show to clients, e.g. in an HTTP response.
+//
+// It resolves to the public message of the outermost error in the chain that has one. If there is none, a generic
+// message based on the effective code of the chain is returned, so internal details of the error messages never
+// reach clients. Error() and ToJSON are not affected and keep the full messages for logs.
+func PublicMessage(err error) string {
+	type PublicMessager interface {
+		PublicMessage() string
+	}
+	var msg string
+	walk(err, func(err error) bool {
+		if publicErr, ok := err.(PublicMessager); ok {
+			msg = publicErr.PublicMessage()
+		}
+		return msg == ""
+	})
+	if msg != "" {
+		return msg
+	}
+	if msg, ok := defaultPublicMessages[EffectiveCode(err, OutermostExplicit)]; ok {
+		return msg
+	}
+	return defaultPublicMessages[DEFAULT_UNKNOWN_CODE]
+}
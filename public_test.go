@@ -0,0 +1,65 @@
+package eris_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestPublicMessage(t *testing.T) {
+	tests := map[string]struct {
+		err error
+		msg string
+	}{
+		"default by code": {
+			err: eris.New("user 42 not found in shard 3").WithCode(eris.CodeNotFound),
+			msg: "The requested resource was not found.",
+		},
+		"default for external": {
+			err: errors.New("dial tcp 10.0.0.1:5432: connection refused"),
+			msg: "An unknown error occurred.",
+		},
+		"default by explicit inner code": {
+			err: eris.Wrap(eris.New("node down").WithCode(eris.CodeUnavailable), "rpc failed"),
+			msg: "The service is currently unavailable, please try again later.",
+		},
+		"root": {
+			err: eris.Wrap(eris.New("user 42 not found").WithPublicMessage("User not found."), "lookup failed"),
+			msg: "User not found.",
+		},
+		"outermost wins": {
+			err: eris.WithPublicMessage(eris.Wrap(eris.New("root").WithPublicMessage("inner"), "wrap"), "outer"),
+			msg: "outer",
+		},
+		"external": {
+			err: eris.WithPublicMessage(errors.New("internal details"), "Please try again."),
+			msg: "Please try again.",
+		},
+		"field": {
+			err: eris.With(eris.New("root"), eris.PublicMessages("field message")),
+			msg: "field message",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if msg := eris.PublicMessage(tc.err); msg != tc.msg {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.msg, msg)
+			}
+		})
+	}
+}
+
+func TestPublicMessageKeepsInternals(t *testing.T) {
+	err := eris.New("user 42 not found in shard 3").WithPublicMessage("User not found.")
+	if !strings.Contains(err.Error(), "shard 3") {
+		t.Errorf("expected Error() to keep the internal message got { %v }", err.Error())
+	}
+	if msg := eris.ToJSON(err, false)["root"].(map[string]any)["message"]; msg != "user 42 not found in shard 3" {
+		t.Errorf("expected ToJSON to keep the internal message got { %v }", msg)
+	}
+	if response := eris.NewResponse(err); strings.Contains(response.String(), "shard") {
+		t.Errorf("expected response without internals got { %v }", response)
+	}
+}
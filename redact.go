@@ -0,0 +1,215 @@
+package eris
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// RedactMode defines how sensitive values are rendered when an error is formatted.
+type RedactMode uint8
+
+const (
+	// RedactMask replaces sensitive values with a fixed placeholder. This is the default mode.
+	RedactMask RedactMode = iota
+	// RedactHash replaces sensitive values with a short hash, so equal values can still be correlated in logs.
+	RedactHash
+	// RedactNone prints sensitive values verbatim.
+	RedactNone
+)
+
+// redactedMarker is the placeholder printed instead of a masked value.
+const redactedMarker = "[redacted]"
+
+// Redactor is implemented by values that know how to render a redacted version of themselves.
+//
+// Values implementing Redactor are redacted in error messages and KVs, unless they are marked as Safe.
+type Redactor interface {
+	Redact() string
+}
+
+// SensitiveValue is a value that must not show up in logs verbatim. Use `eris.Sensitive` to create one.
+type SensitiveValue struct {
+	v any
+}
+
+// Sensitive marks a value as sensitive. Sensitive values can be used as KVs or as arguments of Errorf and Wrapf.
+// They are masked in all output unless the format options say otherwise.
+func Sensitive(v any) SensitiveValue {
+	return SensitiveValue{v: v}
+}
+
+// Value returns the wrapped value.
+func (s SensitiveValue) Value() any {
+	return s.v
+}
+
+// Redact returns the placeholder used for masked values.
+func (s SensitiveValue) Redact() string {
+	return redactedMarker
+}
+
+// String returns the placeholder used for masked values.
+func (s SensitiveValue) String() string {
+	return redactedMarker
+}
+
+// Format prints the placeholder used for masked values, regardless of the verb.
+func (s SensitiveValue) Format(f fmt.State, verb rune) {
+	_, _ = io.WriteString(f, redactedMarker)
+}
+
+// MarshalJSON encodes the placeholder used for masked values.
+func (s SensitiveValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redactedMarker)
+}
+
+// SafeValue is a value that is always printed verbatim. Use `eris.Safe` to create one.
+type SafeValue struct {
+	v any
+}
+
+// Safe marks a value as safe, which exempts it from key based redaction rules and from the Redactor interface.
+func Safe(v any) SafeValue {
+	return SafeValue{v: v}
+}
+
+// Value returns the wrapped value.
+func (s SafeValue) Value() any {
+	return s.v
+}
+
+// String returns the formatted value.
+func (s SafeValue) String() string {
+	return fmt.Sprint(s.v)
+}
+
+// MarshalJSON encodes the wrapped value.
+func (s SafeValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.v)
+}
+
+var (
+	sensitiveKeysMu sync.RWMutex
+	sensitiveKeys   = map[string]struct{}{}
+)
+
+// RegisterSensitiveKeys marks KV keys as sensitive. Values of these keys are redacted in all errors,
+// unless they are wrapped with Safe.
+func RegisterSensitiveKeys(keys ...string) {
+	sensitiveKeysMu.Lock()
+	defer sensitiveKeysMu.Unlock()
+	for _, key := range keys {
+		sensitiveKeys[key] = struct{}{}
+	}
+}
+
+// isSensitiveKey returns true if the key was registered via RegisterSensitiveKeys.
+func isSensitiveKey(key string) bool {
+	sensitiveKeysMu.RLock()
+	defer sensitiveKeysMu.RUnlock()
+	_, ok := sensitiveKeys[key]
+	return ok
+}
+
+// redactValue returns the representation of a KV value or a message argument for the given mode.
+// The key is empty for message arguments.
+func redactValue(key string, v any, mode RedactMode) any {
+	switch val := v.(type) {
+	case SafeValue:
+		return val.v
+	case SensitiveValue:
+		return redactRaw(val.v, val.Redact(), mode)
+	case Redactor:
+		return redactRaw(val, val.Redact(), mode)
+	}
+	if key != "" && isSensitiveKey(key) {
+		return redactRaw(v, redactedMarker, mode)
+	}
+	return v
+}
+
+// redactRaw renders a sensitive value for the given mode, using masked as placeholder.
+func redactRaw(v any, masked string, mode RedactMode) any {
+	switch mode {
+	case RedactNone:
+		return v
+	case RedactHash:
+		sum := sha256.Sum256([]byte(fmt.Sprint(v)))
+		return "[sha256:" + hex.EncodeToString(sum[:4]) + "]"
+	default:
+		return masked
+	}
+}
+
+// redactKVs returns a copy of the KVs with all sensitive values rendered for the given mode.
+func redactKVs(kvs map[string]any, mode RedactMode) map[string]any {
+	if kvs == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(kvs))
+	for k, v := range kvs {
+		redacted[k] = redactValue(k, v, mode)
+	}
+	return redacted
+}
+
+// formatMsg formats a message, rendering all sensitive arguments for the given mode.
+func formatMsg(format string, args []any, mode RedactMode) string {
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		redacted[i] = redactValue("", arg, mode)
+	}
+	return fmt.Sprintf(format, redacted...)
+}
+
+// msgVariants holds the renderings of a message with sensitive arguments for the modes that don't mask them. They
+// are rendered when the error is created, like the masked message, so later changes of the arguments don't affect
+// the error.
+type msgVariants struct {
+	hashed string
+	plain  string
+}
+
+// newMsgVariants renders the message variants for RedactHash and RedactNone. It returns nil if no argument is
+// sensitive, i.e. if the message is the same for all modes.
+func newMsgVariants(format string, args []any) *msgVariants {
+	for _, arg := range args {
+		if _, ok := arg.(Redactor); ok {
+			return &msgVariants{
+				hashed: formatMsg(format, args, RedactHash),
+				plain:  formatMsg(format, args, RedactNone),
+			}
+		}
+	}
+	return nil
+}
+
+// message returns the message rendered for the given mode, where masked is the message rendered for RedactMask.
+func (v *msgVariants) message(masked string, mode RedactMode) string {
+	if v == nil {
+		return masked
+	}
+	switch mode {
+	case RedactHash:
+		return v.hashed
+	case RedactNone:
+		return v.plain
+	default:
+		return masked
+	}
+}
+
+// unwrapValue strips the Sensitive and Safe markers from a value.
+func unwrapValue(v any) any {
+	switch val := v.(type) {
+	case SensitiveValue:
+		return val.v
+	case SafeValue:
+		return val.v
+	}
+	return v
+}
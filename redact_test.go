@@ -0,0 +1,147 @@
+package eris_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+type secretToken string
+
+func (s secretToken) Redact() string { return "tok-***" }
+
+func TestRedactString(t *testing.T) {
+	eris.RegisterSensitiveKeys("test_password")
+
+	tests := map[string]struct {
+		input  error
+		mode   eris.RedactMode
+		output string
+	}{
+		"sensitive kv masked": {
+			input:  eris.New("root error").WithProperty("email", eris.Sensitive("a@b.c")),
+			output: "code(unknown) KVs(map[email:[redacted]]) root error",
+		},
+		"sensitive kv shown": {
+			input:  eris.New("root error").WithProperty("email", eris.Sensitive("a@b.c")),
+			mode:   eris.RedactNone,
+			output: "code(unknown) KVs(map[email:a@b.c]) root error",
+		},
+		"sensitive key masked": {
+			input:  eris.New("root error").WithProperty("test_password", "hunter2"),
+			output: "code(unknown) KVs(map[test_password:[redacted]]) root error",
+		},
+		"safe value not masked": {
+			input:  eris.New("root error").WithProperty("test_password", eris.Safe("hunter2")),
+			output: "code(unknown) KVs(map[test_password:hunter2]) root error",
+		},
+		"redactor kv masked": {
+			input:  eris.New("root error").WithProperty("token", secretToken("abc")),
+			output: "code(unknown) KVs(map[token:tok-***]) root error",
+		},
+		"errorf args masked": {
+			input:  eris.Errorf("user %v not found", eris.Sensitive("alice")),
+			output: "code(unknown) user [redacted] not found",
+		},
+		"errorf args shown": {
+			input:  eris.Errorf("user %v not found", eris.Sensitive("alice")),
+			mode:   eris.RedactNone,
+			output: "code(unknown) user alice not found",
+		},
+		"wrapf args masked": {
+			input:  eris.Wrapf(eris.New("root error"), "token %v", secretToken("abc")),
+			output: "code(internal) token tok-***: code(unknown) root error",
+		},
+		"wrapf args shown": {
+			input:  eris.Wrapf(eris.New("root error"), "token %v", secretToken("abc")),
+			mode:   eris.RedactNone,
+			output: "code(internal) token abc: code(unknown) root error",
+		},
+	}
+	for desc, tt := range tests {
+		t.Run(desc, func(t *testing.T) {
+			format := eris.NewDefaultStringFormat(eris.FormatOptions{WithExternal: true, Redact: tt.mode})
+			if got := eris.ToCustomString(tt.input, format); got != tt.output {
+				t.Errorf("ToCustomString() got\n'%v'\nwant\n'%v'", got, tt.output)
+			}
+		})
+	}
+}
+
+func TestRedactHash(t *testing.T) {
+	err1 := eris.Errorf("user %v not found", eris.Sensitive("alice"))
+	err2 := eris.Errorf("user %v not found", eris.Sensitive("alice"))
+	err3 := eris.Errorf("user %v not found", eris.Sensitive("bob"))
+
+	format := eris.NewDefaultStringFormat(eris.FormatOptions{Redact: eris.RedactHash})
+	str1 := eris.ToCustomString(err1, format)
+	str2 := eris.ToCustomString(err2, format)
+	str3 := eris.ToCustomString(err3, format)
+	if str1 != str2 {
+		t.Errorf("expected equal values to have the same hash, got { %v } and { %v }", str1, str2)
+	}
+	if str1 == str3 {
+		t.Errorf("expected different values to have different hashes, got { %v } and { %v }", str1, str3)
+	}
+	if strings.Contains(str1, "alice") {
+		t.Errorf("expected value to be hashed, got { %v }", str1)
+	}
+}
+
+func TestRedactOutputPaths(t *testing.T) {
+	err := eris.Wrapf(
+		eris.New("root error").WithProperty("email", eris.Sensitive("a@b.c")),
+		"lookup of %v failed", eris.Sensitive("alice"),
+	)
+
+	outputs := map[string]string{
+		"Error()":  err.Error(),
+		"%v":       fmt.Sprintf("%v", err),
+		"%+v":      fmt.Sprintf("%+v", err),
+		"ToString": eris.ToString(err, true),
+	}
+	j, _ := json.Marshal(eris.ToJSON(err, true))
+	outputs["ToJSON"] = string(j)
+	for desc, out := range outputs {
+		if strings.Contains(out, "a@b.c") || strings.Contains(out, "alice") {
+			t.Errorf("%v: expected sensitive values to be redacted, got { %v }", desc, out)
+		}
+	}
+
+	j, _ = json.Marshal(eris.ToCustomJSON(err, eris.NewDefaultJSONFormat(eris.FormatOptions{Redact: eris.RedactNone})))
+	expected := `{"root":{"KVs":{"email":"a@b.c"},"code":"unknown","message":"root error"},"wrap":[{"code":"internal","message":"lookup of alice failed"}]}`
+	if string(j) != expected {
+		t.Errorf("expected { %v } got { %v }", expected, string(j))
+	}
+}
+
+func TestRedactArgsMutated(t *testing.T) {
+	batch := []int{1, 2}
+	users := []string{"alice"}
+	err := eris.Wrapf(eris.Errorf("batch %v failed", batch), "lookup of %v failed", eris.Sensitive(users))
+	batch[0], users[0] = 99, "bob"
+
+	if msg := err.Error(); msg != "code(internal) lookup of [redacted] failed: code(unknown) batch [1 2] failed" {
+		t.Errorf("expected message rendered at creation got { %v }", msg)
+	}
+	format := eris.NewDefaultStringFormat(eris.FormatOptions{Redact: eris.RedactNone})
+	expected := "code(internal) lookup of [alice] failed: code(unknown) batch [1 2] failed"
+	if str := eris.ToCustomString(err, format); str != expected {
+		t.Errorf("expected { %v } got { %v }", expected, str)
+	}
+}
+
+func TestRedactProperty(t *testing.T) {
+	err := eris.New("root error").WithProperty("email", eris.Sensitive("a@b.c"))
+	email, ok := eris.GetProperty[string](err, "email")
+	if !ok || email != "a@b.c" {
+		t.Errorf("expected { %v } got { %v, %v }", "a@b.c", email, ok)
+	}
+	if !reflect.DeepEqual(eris.GetKVs(err)["email"], eris.Sensitive("a@b.c")) {
+		t.Errorf("expected KVs to keep the sensitive marker, got { %v }", eris.GetKVs(err))
+	}
+}
@@ -0,0 +1,90 @@
+package eris
+
+import (
+	"strings"
+
+	grpc "google.golang.org/grpc/codes"
+)
+
+// Response is the representation of an error that is sent to clients, e.g. as body of an HTTP response or as
+// message of a gRPC status. It carries no stack traces, KVs or internal messages.
+type Response struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Detail  string   `json:"detail,omitempty"`
+	Hints   []string `json:"hints,omitempty"`
+	DocURL  string   `json:"docURL,omitempty"`
+}
+
+// NewResponse returns the response for an error. The code is the effective code of the chain, see EffectiveCode,
+// and the message is the public message, see PublicMessage.
+func NewResponse(err error) Response {
+	return Response{
+		Code:    EffectiveCode(err, OutermostExplicit).String(),
+		Message: PublicMessage(err),
+		Detail:  GetDetail(err),
+		Hints:   GetHints(err),
+		DocURL:  GetDocURL(err),
+	}
+}
+
+// String returns the response in the style of Postgres error messages, with detail, hints and documentation
+// link on separate lines:
+//
+//	column "nme" does not exist
+//	DETAIL: table "users" has columns "id" and "name"
+//	HINT: perhaps you meant to reference the column "name"
+//	DOC: https://docs.example.com/errors/undefined-column
+func (r Response) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Message)
+	if r.Detail != "" {
+		sb.WriteString("\nDETAIL: " + r.Detail)
+	}
+	for _, hint := range r.Hints {
+		sb.WriteString("\nHINT: " + hint)
+	}
+	if r.DocURL != "" {
+		sb.WriteString("\nDOC: " + r.DocURL)
+	}
+	return sb.String()
+}
+
+// HTTPStatuses returns a Field of HTTPStatusType.
+func HTTPStatuses(status HTTPStatus) Field {
+	return Field{
+		Type:  HTTPStatusType,
+		Value: status,
+	}
+}
+
+// WithHTTPStatus overrides the HTTP status that ToHTTP derives from the code of an error. Like hints, the
+// override is stored separately from the KVs.
+func WithHTTPStatus(err error, status HTTPStatus) error {
+	return With(err, HTTPStatuses(status))
+}
+
+// ToHTTP returns the HTTP status and the response body for an error. The status is derived from the effective
+// code, unless it is overridden via WithHTTPStatus. The outermost override wins.
+func ToHTTP(err error) (HTTPStatus, Response) {
+	type HTTPStatuser interface {
+		HTTPStatus() HTTPStatus
+	}
+	var status HTTPStatus
+	walk(err, func(err error) bool {
+		if statusErr, ok := err.(HTTPStatuser); ok {
+			status = statusErr.HTTPStatus()
+		}
+		return status == 0
+	})
+	if status != 0 {
+		return status, NewResponse(err)
+	}
+	return EffectiveCode(err, OutermostExplicit).ToHttp(), NewResponse(err)
+}
+
+// ToGrpc returns the gRPC code and the status message for an error. The message includes the detail, hints and
+// documentation link, see Response.String.
+func ToGrpc(err error) (grpc.Code, string) {
+	return EffectiveCode(err, OutermostExplicit).ToGrpc(), NewResponse(err).String()
+}
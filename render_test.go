@@ -0,0 +1,79 @@
+package eris_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+	grpc "google.golang.org/grpc/codes"
+)
+
+func TestResponse(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		response eris.Response
+		str      string
+		http     eris.HTTPStatus
+		grpc     grpc.Code
+	}{
+		"plain": {
+			err:      eris.Wrap(errors.New("connection refused"), "failed to connect"),
+			response: eris.Response{Code: "internal", Message: "An internal error occurred."},
+			str:      "An internal error occurred.",
+			http:     http.StatusInternalServerError,
+			grpc:     grpc.Internal,
+		},
+		"with hints": {
+			err: eris.Wrap(eris.New(`column "nme" does not exist`).WithCode(eris.CodeInvalidArgument).
+				WithPublicMessage(`column "nme" does not exist`).
+				WithDetail(`table "users" has columns "id" and "name"`).
+				WithHint(`perhaps you meant to reference the column "name"`), "query failed"),
+			response: eris.Response{
+				Code:    "invalid argument",
+				Message: `column "nme" does not exist`,
+				Detail:  `table "users" has columns "id" and "name"`,
+				Hints:   []string{`perhaps you meant to reference the column "name"`},
+			},
+			str:  "column \"nme\" does not exist\nDETAIL: table \"users\" has columns \"id\" and \"name\"\nHINT: perhaps you meant to reference the column \"name\"",
+			http: http.StatusBadRequest,
+			grpc: grpc.InvalidArgument,
+		},
+		"http status override": {
+			err:      eris.WithHTTPStatus(eris.New("conflict").WithCode(eris.CodeAborted), http.StatusConflict),
+			response: eris.Response{Code: "aborted", Message: "The operation was aborted, please try again."},
+			str:      "The operation was aborted, please try again.",
+			http:     http.StatusConflict,
+			grpc:     grpc.Aborted,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if response := eris.NewResponse(tc.err); !reflect.DeepEqual(response, tc.response) {
+				t.Errorf("%v: expected { %+v } got { %+v }", desc, tc.response, response)
+			}
+			status, body := eris.ToHTTP(tc.err)
+			if status != tc.http || !reflect.DeepEqual(body, tc.response) {
+				t.Errorf("%v: expected { %v, %+v } got { %v, %+v }", desc, tc.http, tc.response, status, body)
+			}
+			code, msg := eris.ToGrpc(tc.err)
+			if code != tc.grpc || msg != tc.str {
+				t.Errorf("%v: expected { %v, %v } got { %v, %v }", desc, tc.grpc, tc.str, code, msg)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusOverride(t *testing.T) {
+	err := eris.Wrap(eris.WithHTTPStatus(eris.New("conflict").WithCode(eris.CodeAborted), http.StatusConflict), "wrap")
+	if msg := err.Error(); msg != "code(internal) wrap: code(aborted) conflict" {
+		t.Errorf("expected HTTP status not to be rendered got { %v }", msg)
+	}
+	if eris.HasProperty(err, "http_status") {
+		t.Errorf("expected HTTP status not to be stored as property")
+	}
+	if status, _ := eris.ToHTTP(eris.WithHTTPStatus(err, http.StatusTooManyRequests)); status != http.StatusTooManyRequests {
+		t.Errorf("expected outermost override { %v } got { %v }", http.StatusTooManyRequests, status)
+	}
+}
@@ -0,0 +1,236 @@
+package eris
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Report is a group of equal errors passed to the sink of a Reporter, see Fingerprint.
+type Report struct {
+	Fingerprint string    // Fingerprint shared by all errors of the group.
+	Err         error     // First error of the group.
+	Summary     bool      // Flag indicating whether this is a periodic summary instead of the first occurrence.
+	Count       int       // Number of occurrences since the previous report of the group.
+	Total       int       // Number of occurrences since the group was first seen.
+	FirstSeen   time.Time // Time of the first occurrence.
+	LastSeen    time.Time // Time of the most recent occurrence.
+}
+
+// Sink receives the reports of a Reporter. Sinks may be called concurrently.
+type Sink func(Report)
+
+// WriterSink returns a sink that writes one line per report to w, e.g.
+//
+//	code(unavailable) node down (repeated 42 times between 12:00:00 and 12:01:00)
+func WriterSink(w io.Writer) Sink {
+	var mu sync.Mutex
+	return func(r Report) {
+		line := ToString(r.Err, false)
+		if r.Summary {
+			line += fmt.Sprintf(" (repeated %d times between %s and %s)", r.Count, r.FirstSeen.Format(time.TimeOnly), r.LastSeen.Format(time.TimeOnly))
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = io.WriteString(w, line+"\n")
+	}
+}
+
+// ReporterOptions configures a Reporter.
+type ReporterOptions struct {
+	Interval  time.Duration // Interval between summaries, defaults to one minute.
+	MaxGroups int           // Maximum number of tracked groups, defaults to 1000.
+	// Now returns the current time, defaults to time.Now. Tests can set it to control when groups become idle.
+	Now func() time.Time
+}
+
+// overflowFingerprint is the fingerprint of the group that collects errors exceeding ReporterOptions.MaxGroups.
+const overflowFingerprint = "overflow"
+
+// reportGroup tracks the occurrences of errors with the same fingerprint.
+type reportGroup struct {
+	err       error
+	count     int // occurrences since the previous report
+	total     int
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// Reporter deduplicates errors of noisy code paths, e.g. loops that fail thousands of times per second.
+//
+// Errors are grouped by Fingerprint. The first error of a group is passed to the sink immediately, further
+// occurrences are only counted and passed as periodic summaries. Groups without occurrences for a whole interval
+// are dropped, so a recurring error is reported immediately again. The number of groups is bounded: errors that
+// would exceed ReporterOptions.MaxGroups are counted in a single group with the fingerprint "overflow", which is
+// reported with an error of code 'resource exhausted' instead of one of the counted errors.
+//
+// A Reporter is safe for concurrent use. Call Close to stop the periodic summaries.
+type Reporter struct {
+	sink      Sink
+	interval  time.Duration
+	maxGroups int
+	now       func() time.Time
+
+	mu     sync.Mutex
+	groups map[string]*reportGroup
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewReporter returns a reporter that passes reports to sink and starts emitting periodic summaries.
+func NewReporter(sink Sink, opts ReporterOptions) *Reporter {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.MaxGroups <= 0 {
+		opts.MaxGroups = 1000
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	r := &Reporter{
+		sink:      sink,
+		interval:  opts.Interval,
+		maxGroups: opts.MaxGroups,
+		now:       opts.Now,
+		groups:    make(map[string]*reportGroup),
+		done:      make(chan struct{}),
+	}
+	r.wg.Add(1)
+	go r.run()
+	return r
+}
+
+// run emits summaries until the reporter is closed.
+func (r *Reporter) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.Flush()
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// Report records an error. The first error of a group is passed to the sink immediately. Nil errors are ignored.
+func (r *Reporter) Report(err error) {
+	if err == nil {
+		return
+	}
+	fingerprint := Fingerprint(err)
+	now := r.now()
+
+	r.mu.Lock()
+	group, ok := r.groups[fingerprint]
+	if !ok && len(r.groups) >= r.maxGroups {
+		fingerprint = overflowFingerprint
+		group, ok = r.groups[fingerprint]
+	}
+	if ok {
+		group.count++
+		group.total++
+		group.lastSeen = now
+		r.mu.Unlock()
+		return
+	}
+	if fingerprint == overflowFingerprint {
+		// the overflow group collects unrelated errors, so none of them represents the group
+		err = Errorf("errors exceeding the limit of %d groups", r.maxGroups).WithCode(CodeResourceExhausted)
+	}
+	group = &reportGroup{err: err, total: 1, firstSeen: now, lastSeen: now}
+	r.groups[fingerprint] = group
+	r.mu.Unlock()
+
+	r.sink(Report{
+		Fingerprint: fingerprint,
+		Err:         err,
+		Count:       1,
+		Total:       1,
+		FirstSeen:   now,
+		LastSeen:    now,
+	})
+}
+
+// Flush passes a summary of every group with occurrences since its previous report to the sink, and drops the
+// groups without occurrences for a whole interval.
+func (r *Reporter) Flush() {
+	now := r.now()
+	var reports []Report
+	r.mu.Lock()
+	for fingerprint, group := range r.groups {
+		if group.count == 0 {
+			if now.Sub(group.lastSeen) >= r.interval {
+				delete(r.groups, fingerprint)
+			}
+			continue
+		}
+		reports = append(reports, Report{
+			Fingerprint: fingerprint,
+			Err:         group.err,
+			Summary:     true,
+			Count:       group.count,
+			Total:       group.total,
+			FirstSeen:   group.firstSeen,
+			LastSeen:    group.lastSeen,
+		})
+		group.count = 0
+	}
+	r.mu.Unlock()
+
+	for _, report := range reports {
+		r.sink(report)
+	}
+}
+
+// Close stops the periodic summaries and flushes the pending ones. It is safe to call Close multiple times.
+func (r *Reporter) Close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+		r.wg.Wait()
+		r.Flush()
+	})
+}
+
+// Fingerprint returns the fingerprint by which a Reporter groups errors. It consists of the effective code, the
+// message template of the root error and the frame where the root error was created, so errors created at the
+// same place are grouped even if their message arguments differ. If the root error wraps an external error, the
+// type of the external error is added, and its message if the root error was created without template, e.g. via
+// Wrap. For chains without root error, the type and message of the innermost error are used instead.
+func Fingerprint(err error) string {
+	code := EffectiveCode(err, OutermostExplicit)
+	var root *rootError
+	walk(err, func(err error) bool {
+		if r, ok := err.(*rootError); ok {
+			root = r
+			return false
+		}
+		return true
+	})
+	if root == nil {
+		inner := err
+		for Unwrap(inner) != nil {
+			inner = Unwrap(inner)
+		}
+		return fmt.Sprintf("%s|%T|%s", code, inner, inner.Error())
+	}
+	var origin string
+	if root.stack != nil && len(*root.stack) > 0 {
+		f := frame((*root.stack)[0]).get()
+		origin = f.format(":", FormatOptions{})
+	}
+	fingerprint := fmt.Sprintf("%s|%s|%s", code, messageTemplate(root.format, root.msg), origin)
+	if root.ext != nil {
+		fingerprint += fmt.Sprintf("|%T", root.ext)
+		if root.format == "" {
+			fingerprint += "|" + root.ext.Error()
+		}
+	}
+	return fingerprint
+}
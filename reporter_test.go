@@ -0,0 +1,203 @@
+package eris_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/risingwavelabs/eris"
+)
+
+// reportRecorder is a sink that records all reports.
+type reportRecorder struct {
+	mu      sync.Mutex
+	reports []eris.Report
+}
+
+func (r *reportRecorder) sink(report eris.Report) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, report)
+}
+
+func (r *reportRecorder) get() []eris.Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]eris.Report(nil), r.reports...)
+}
+
+func scanTable(name string) error {
+	return eris.Errorf("failed to scan %v", name).WithCode(eris.CodeUnavailable)
+}
+
+func TestReporter(t *testing.T) {
+	recorder := &reportRecorder{}
+	reporter := eris.NewReporter(recorder.sink, eris.ReporterOptions{Interval: time.Hour})
+	defer reporter.Close()
+
+	for _, name := range []string{"users", "orders", "users"} {
+		reporter.Report(eris.Wrap(scanTable(name), "query failed"))
+	}
+	reporter.Report(nil)
+	reporter.Report(errors.New("external"))
+
+	reports := recorder.get()
+	if len(reports) != 2 {
+		t.Fatalf("expected { 2 } first occurrences got { %v }", reports)
+	}
+	if reports[0].Summary || reports[0].Count != 1 || !strings.HasSuffix(reports[0].Err.Error(), "failed to scan users") {
+		t.Errorf("unexpected first occurrence { %+v }", reports[0])
+	}
+
+	reporter.Flush()
+	reports = recorder.get()
+	if len(reports) != 3 {
+		t.Fatalf("expected { 1 } summary got { %v }", reports[2:])
+	}
+	summary := reports[2]
+	if !summary.Summary || summary.Count != 2 || summary.Total != 3 || summary.Fingerprint != reports[0].Fingerprint {
+		t.Errorf("unexpected summary { %+v }", summary)
+	}
+	if summary.LastSeen.Before(summary.FirstSeen) {
+		t.Errorf("expected last seen { %v } after first seen { %v }", summary.LastSeen, summary.FirstSeen)
+	}
+
+	reporter.Flush()
+	if reports = recorder.get(); len(reports) != 3 {
+		t.Errorf("expected no summaries without new occurrences got { %v }", reports[3:])
+	}
+}
+
+func TestReporterOverflow(t *testing.T) {
+	recorder := &reportRecorder{}
+	reporter := eris.NewReporter(recorder.sink, eris.ReporterOptions{Interval: time.Hour, MaxGroups: 2})
+	for i := 0; i < 5; i++ {
+		reporter.Report(eris.New("error " + string(rune('a'+i))))
+	}
+	reporter.Close()
+	reporter.Close()
+
+	var fingerprints []string
+	for _, report := range recorder.get() {
+		fingerprints = append(fingerprints, report.Fingerprint)
+	}
+	if len(fingerprints) != 4 || fingerprints[2] != "overflow" || fingerprints[3] != "overflow" {
+		t.Fatalf("expected two groups and the overflow group got { %v }", fingerprints)
+	}
+	for _, overflow := range recorder.get()[2:] {
+		if msg := overflow.Err.Error(); msg != "code(resource exhausted) errors exceeding the limit of 2 groups" {
+			t.Errorf("expected dedicated overflow error got { %v }", msg)
+		}
+	}
+	if overflow := recorder.get()[3]; overflow.Count != 2 || overflow.Total != 3 {
+		t.Errorf("unexpected overflow summary { %+v }", overflow)
+	}
+}
+
+func TestReporterConcurrent(t *testing.T) {
+	recorder := &reportRecorder{}
+	reporter := eris.NewReporter(recorder.sink, eris.ReporterOptions{Interval: time.Millisecond})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				reporter.Report(scanTable("users"))
+			}
+		}()
+	}
+	wg.Wait()
+	reporter.Close()
+
+	total := 0
+	for _, report := range recorder.get() {
+		total += report.Count
+	}
+	if total != 1000 {
+		t.Errorf("expected { 1000 } occurrences got { %v }", total)
+	}
+}
+
+func TestReporterIdleGroups(t *testing.T) {
+	recorder := &reportRecorder{}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reporter := eris.NewReporter(recorder.sink, eris.ReporterOptions{
+		Interval: time.Hour,
+		Now:      func() time.Time { return now },
+	})
+	defer reporter.Close()
+
+	reporter.Report(scanTable("users"))
+	now = now.Add(30 * time.Minute)
+	reporter.Flush()
+	reporter.Report(scanTable("users"))
+	if reports := recorder.get(); len(reports) != 1 {
+		t.Fatalf("expected the group to be kept before an idle interval got { %v }", reports)
+	}
+
+	reporter.Flush()
+	now = now.Add(time.Hour)
+	reporter.Flush()
+	reporter.Report(scanTable("users"))
+	reports := recorder.get()
+	if len(reports) != 3 || !reports[1].Summary || reports[2].Summary {
+		t.Fatalf("expected the error to be reported again after an idle interval got { %v }", reports)
+	}
+	if !reports[2].FirstSeen.Equal(now) {
+		t.Errorf("expected first seen { %v } got { %v }", now, reports[2].FirstSeen)
+	}
+}
+
+func TestReporterSinks(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := eris.NewReporter(eris.WriterSink(&buf), eris.ReporterOptions{Interval: time.Hour})
+	for i := 0; i < 3; i++ {
+		reporter.Report(scanTable("users"))
+	}
+	reporter.Close()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != "code(unavailable) failed to scan users" || !strings.HasPrefix(lines[1], "code(unavailable) failed to scan users (repeated 2 times between ") {
+		t.Errorf("unexpected output { %v }", buf.String())
+	}
+
+}
+
+func TestFingerprint(t *testing.T) {
+	users, orders := scanTable("users"), scanTable("orders")
+	if eris.Fingerprint(users) != eris.Fingerprint(orders) {
+		t.Errorf("expected equal fingerprints for different arguments got { %v } and { %v }", eris.Fingerprint(users), eris.Fingerprint(orders))
+	}
+	if eris.Fingerprint(users) == eris.Fingerprint(eris.WithCode(orders, eris.CodeDataLoss)) {
+		t.Errorf("expected different fingerprints for different codes")
+	}
+	if eris.Fingerprint(users) == eris.Fingerprint(eris.Errorf("failed to scan %v", "users").WithCode(eris.CodeUnavailable)) {
+		t.Errorf("expected different fingerprints for different origins")
+	}
+	if !strings.Contains(eris.Fingerprint(users), "|failed to scan %v|eris_test.scanTable:") {
+		t.Errorf("unexpected fingerprint { %v }", eris.Fingerprint(users))
+	}
+	if fp := eris.Fingerprint(errors.New("external")); fp != "unknown|*errors.errorString|external" {
+		t.Errorf("unexpected fingerprint { %v }", fp)
+	}
+
+	// wrapped external errors are told apart by their type and, without template, their message
+	readFile := func(err error) error { return eris.Wrap(err, "read failed") }
+	if eris.Fingerprint(readFile(io.EOF)) == eris.Fingerprint(readFile(errors.New("permission denied"))) {
+		t.Errorf("expected different fingerprints for different external errors")
+	}
+	if eris.Fingerprint(readFile(io.EOF)) != eris.Fingerprint(readFile(io.EOF)) {
+		t.Errorf("expected equal fingerprints for equal external errors")
+	}
+	readTable := func(err error, name string) error { return eris.Wrapf(err, "read %v failed", name) }
+	if eris.Fingerprint(readTable(io.EOF, "users")) != eris.Fingerprint(readTable(io.ErrUnexpectedEOF, "orders")) {
+		t.Errorf("expected equal fingerprints for templates wrapping external errors of the same type")
+	}
+	if fp := eris.Fingerprint(readFile(io.EOF)); !strings.HasSuffix(fp, "|*errors.errorString|EOF") {
+		t.Errorf("unexpected fingerprint { %v }", fp)
+	}
+}
@@ -0,0 +1,128 @@
+package eris
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+var (
+	retryableKey  = NewKey[bool]("retryable")
+	retryAfterKey = NewKey[time.Duration]("retry_after")
+)
+
+// WithRetryable overrides whether an error is retryable, regardless of its code.
+func WithRetryable(err error, retryable bool) error {
+	return WithKey(err, retryableKey, retryable)
+}
+
+// WithRetryAfter marks an error as retryable after the given delay, e.g. as requested by a rate limiter.
+// Retry waits at least this long before the next attempt.
+func WithRetryAfter(err error, d time.Duration) error {
+	return WithKey(err, retryAfterKey, d)
+}
+
+// IsRetryable reports whether the operation that failed with err can be retried.
+//
+// The outermost override set via WithRetryable decides if there is one. Otherwise errors with a delay set via
+// WithRetryAfter are retryable, and all other errors are retryable if their effective code is, see
+// Code.Retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if retryable, ok := retryableKey.Find(err); ok {
+		return retryable
+	}
+	if _, ok := retryAfterKey.Find(err); ok {
+		return true
+	}
+	return EffectiveCode(err, OutermostExplicit).Retryable()
+}
+
+// RetryAfter returns the delay set via WithRetryAfter by the outermost error in the chain that sets it.
+func RetryAfter(err error) (time.Duration, bool) {
+	return retryAfterKey.Find(err)
+}
+
+// RetryOptions configures Retry. Zero values are replaced by the defaults.
+type RetryOptions struct {
+	MaxAttempts    int           // maximum number of attempts, defaults to 3
+	InitialBackoff time.Duration // delay before the second attempt, defaults to 100ms
+	MaxBackoff     time.Duration // maximum delay between attempts, defaults to 10s
+	Multiplier     float64       // factor by which the delay grows after each attempt, defaults to 2
+	Jitter         float64       // maximum random deviation of a delay as fraction of it, e.g. 0.2 for ±20%
+}
+
+// withDefaults returns the options with all zero values replaced by the defaults.
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 3
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = 100 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 10 * time.Second
+	}
+	if o.Multiplier <= 0 {
+		o.Multiplier = 2
+	}
+	return o
+}
+
+// backoff returns the delay before the attempt following the given one, starting at 1.
+func (o RetryOptions) backoff(attempt int) time.Duration {
+	d := float64(o.InitialBackoff)
+	for i := 1; i < attempt && d < float64(o.MaxBackoff); i++ {
+		d *= o.Multiplier
+	}
+	if d > float64(o.MaxBackoff) {
+		d = float64(o.MaxBackoff)
+	}
+	if o.Jitter > 0 {
+		d *= 1 + o.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(d)
+}
+
+// Retry calls fn until it succeeds, returns a non-retryable error (see IsRetryable) or the maximum number of
+// attempts is reached. Between attempts it waits with exponential backoff and jitter, or longer if the error asks
+// for it via WithRetryAfter.
+//
+// If fn never succeeds, Retry returns the errors of all attempts joined into one, see Multi. Each of them is
+// wrapped with property "attempt", starting at 1. If ctx is done while waiting, its error is added as well, with
+// code 'canceled' or 'deadline exceeded'.
+func Retry(ctx context.Context, fn func(ctx context.Context) error, opts RetryOptions) error {
+	opts = opts.withDefaults()
+	site := callers(3)
+	var errs Multi
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		errs.Append(wrapAttempt(err, attempt, site))
+		if attempt >= opts.MaxAttempts || !IsRetryable(err) {
+			return errs.ErrorOrNil()
+		}
+
+		delay := opts.backoff(attempt)
+		if after, ok := RetryAfter(err); ok && after > delay {
+			delay = after
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			errs.Append(WithCode(wrapAt(ctx.Err(), "retry interrupted", site), contextCode(ctx.Err(), CodeUnknown)))
+			return errs.ErrorOrNil()
+		case <-timer.C:
+		}
+	}
+}
+
+// wrapAttempt wraps the error of an attempt with its number and the frame where Retry was called.
+func wrapAttempt(err error, attempt int, site *stack) error {
+	return With(wrapAt(err, "attempt failed", site), KVs("attempt", attempt))
+}
@@ -0,0 +1,167 @@
+package eris_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		retryable bool
+	}{
+		"nil": {
+			err:       nil,
+			retryable: false,
+		},
+		"unavailable": {
+			err:       eris.New("node down").WithCode(eris.CodeUnavailable),
+			retryable: true,
+		},
+		"wrapped aborted": {
+			err:       eris.Wrap(eris.New("txn conflict").WithCode(eris.CodeAborted), "commit failed"),
+			retryable: true,
+		},
+		"invalid argument": {
+			err:       eris.New("bad query").WithCode(eris.CodeInvalidArgument),
+			retryable: false,
+		},
+		"external": {
+			err:       errors.New("external"),
+			retryable: false,
+		},
+		"override": {
+			err:       eris.WithRetryable(eris.New("node down").WithCode(eris.CodeUnavailable), false),
+			retryable: false,
+		},
+		"outermost override wins": {
+			err:       eris.WithRetryable(eris.Wrap(eris.WithRetryable(eris.New("inner"), false), "outer"), true),
+			retryable: true,
+		},
+		"retry after": {
+			err:       eris.WithRetryAfter(eris.New("rate limited"), time.Second),
+			retryable: true,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if retryable := eris.IsRetryable(tc.err); retryable != tc.retryable {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.retryable, retryable)
+			}
+		})
+	}
+
+	err := eris.Wrap(eris.WithRetryAfter(eris.New("rate limited"), time.Second), "outer")
+	if d, ok := eris.RetryAfter(err); !ok || d != time.Second {
+		t.Errorf("expected { %v } got { %v, %v }", time.Second, d, ok)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	opts := eris.RetryOptions{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Jitter:         0.5,
+	}
+	tests := map[string]struct {
+		errs     []error
+		attempts int
+		msg      string
+		code     eris.Code
+	}{
+		"success": {
+			errs:     []error{nil},
+			attempts: 1,
+		},
+		"success after retries": {
+			errs: []error{
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				nil,
+			},
+			attempts: 3,
+		},
+		"not retryable": {
+			errs: []error{
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				eris.New("table missing").WithCode(eris.CodeNotFound),
+			},
+			attempts: 2,
+			msg:      "2 errors occurred",
+			code:     eris.CodeUnavailable,
+		},
+		"attempts exhausted": {
+			errs: []error{
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				eris.New("node down").WithCode(eris.CodeUnavailable),
+				nil,
+			},
+			attempts: 4,
+			msg:      "4 errors occurred",
+			code:     eris.CodeUnavailable,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			attempts := 0
+			err := eris.Retry(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return tc.errs[attempts-1]
+			}, opts)
+			if attempts != tc.attempts {
+				t.Errorf("%v: expected { %v } attempts got { %v }", desc, tc.attempts, attempts)
+			}
+			if tc.msg == "" {
+				if err != nil {
+					t.Errorf("%v: expected nil error got { %v }", desc, err)
+				}
+				return
+			}
+			if msg := eris.Unpack(err).ErrRoot.Msg; msg != tc.msg {
+				t.Errorf("%v: expected message { %v } got { %v }", desc, tc.msg, msg)
+			}
+			if code := eris.GetCode(err); code != tc.code {
+				t.Errorf("%v: expected code { %v } got { %v }", desc, tc.code, code)
+			}
+			joined, ok := eris.Unpack(err).ErrExternal.(interface{ Unwrap() []error })
+			if !ok || len(joined.Unwrap()) != tc.attempts {
+				t.Fatalf("%v: expected { %v } joined errors got { %v }", desc, tc.attempts, err)
+			}
+			for i, attemptErr := range joined.Unwrap() {
+				if n, _ := eris.GetProperty[int](attemptErr, "attempt"); n != i+1 {
+					t.Errorf("%v: expected attempt { %v } got { %v }", desc, i+1, n)
+				}
+				// the attempt link points to the caller of Retry
+				view, _ := eris.Find(attemptErr, func(eris.ErrView) bool { return true })
+				if !strings.HasPrefix(view.Frame.Name, "eris_test.TestRetry.") {
+					t.Errorf("%v: expected attempt frame in { %v } got { %v }", desc, "eris_test.TestRetry", view.Frame.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := eris.Retry(ctx, func(ctx context.Context) error {
+		cancel()
+		return eris.WithRetryAfter(eris.New("rate limited"), time.Hour)
+	}, eris.RetryOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context error to be reachable via Is got { %v }", err)
+	}
+	if n := eris.Unpack(err).Children; len(n) != 2 {
+		t.Errorf("expected { %v } children got { %v }", 2, len(n))
+	}
+	if _, ok := eris.Find(err, func(view eris.ErrView) bool { return view.Code == eris.CodeCanceled }); !ok {
+		t.Errorf("expected context error with code { %v } in { %v }", eris.CodeCanceled, err)
+	}
+}
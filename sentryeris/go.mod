@@ -0,0 +1,14 @@
+module github.com/risingwavelabs/eris/sentryeris
+
+go 1.21
+
+require (
+	github.com/getsentry/sentry-go v0.33.0
+	github.com/risingwavelabs/eris v0.1.0
+)
+
+require (
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/grpc v1.53.0 // indirect
+)
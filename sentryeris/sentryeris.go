@@ -0,0 +1,188 @@
+// Package sentryeris converts eris errors into Sentry events.
+//
+//	sentryeris.CaptureError(sentry.CurrentHub(), err, sentryeris.Options{})
+//
+// Each error of the chain becomes an exception with its own stack trace, the effective code and severity become
+// tags, and the key-value pairs of the chain become the "kvs" context. Events are grouped by the messages the
+// errors were created from, so errors that only differ in their arguments end up in the same issue.
+package sentryeris
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/risingwavelabs/eris"
+)
+
+// Options configures the conversion of errors into events.
+type Options struct {
+	// Module or package path prefixes whose frames are marked as in-app. Defaults to the application modules,
+	// see eris.StackFrame.Module.
+	InAppModules []string
+}
+
+// NewEvent converts an error into a Sentry event. It returns nil for a nil error.
+//
+// The exceptions are ordered from the root cause to the outermost error, as expected by Sentry. The type of
+// each exception is the ID of the definition the error was created from or its code, and the value is its
+// message. Sensitive values are masked in messages and KVs.
+func NewEvent(err error, opts Options) *sentry.Event {
+	if err == nil {
+		return nil
+	}
+	upErr := eris.Unpack(err)
+
+	event := sentry.NewEvent()
+	event.Level = level(eris.GetSeverity(err))
+	event.Exception = exceptions(upErr, opts.InAppModules)
+	event.Fingerprint = fingerprint(upErr)
+
+	event.Tags["code"] = eris.EffectiveCode(err, eris.OutermostExplicit).String()
+	event.Tags["severity"] = eris.GetSeverity(err).String()
+	if id := eris.GetID(err); id != "" {
+		event.Tags["error_id"] = id
+	}
+	if domain := eris.Domain(err); domain != "" {
+		event.Tags["domain"] = domain
+	}
+	if component := eris.Component(err); component != "" {
+		event.Tags["component"] = component
+	}
+	if kvs := upErr.KVs(); len(kvs) > 0 {
+		event.Contexts["kvs"] = kvs
+	}
+	return event
+}
+
+// CaptureError converts an error into an event and sends it via the hub. It returns nil for a nil error or if
+// the event was dropped. A nil hub falls back to the current hub.
+func CaptureError(hub *sentry.Hub, err error, opts Options) *sentry.EventID {
+	if err == nil {
+		return nil
+	}
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+	return hub.CaptureEvent(NewEvent(err, opts))
+}
+
+// level returns the Sentry level of a severity.
+func level(sev eris.Severity) sentry.Level {
+	switch sev {
+	case eris.SeverityDebug:
+		return sentry.LevelDebug
+	case eris.SeverityInfo:
+		return sentry.LevelInfo
+	case eris.SeverityWarning:
+		return sentry.LevelWarning
+	case eris.SeverityCritical:
+		return sentry.LevelFatal
+	default:
+		return sentry.LevelError
+	}
+}
+
+// exceptions returns one exception per error of the chain, starting with the root cause.
+func exceptions(upErr eris.UnpackedError, inApp []string) []sentry.Exception {
+	var excs []sentry.Exception
+	if upErr.ErrExternal != nil {
+		excs = append(excs, sentry.Exception{
+			Type:  fmt.Sprintf("%T", upErr.ErrExternal),
+			Value: upErr.ErrExternal.Error(),
+		})
+	}
+	root := upErr.ErrRoot
+	if root.Msg != "" || len(root.Stack) > 0 {
+		excs = append(excs, sentry.Exception{
+			Type:       exceptionType(root.ID(), root.Code()),
+			Value:      root.Msg,
+			Stacktrace: stacktrace(root.Stack, inApp),
+		})
+	}
+	for _, link := range upErr.ErrChain {
+		excs = append(excs, sentry.Exception{
+			Type:       exceptionType(link.ID(), link.Code()),
+			Value:      link.Msg,
+			Stacktrace: stacktrace(eris.Stack{link.Frame}, inApp),
+		})
+	}
+	return excs
+}
+
+// exceptionType returns the ID of the definition an error was created from, or its code if it has none.
+func exceptionType(id string, code eris.Code) string {
+	if id != "" {
+		return id
+	}
+	return code.String()
+}
+
+// stacktrace converts an eris stack into a Sentry stack trace, which lists the most recent call last.
+func stacktrace(stack eris.Stack, inApp []string) *sentry.Stacktrace {
+	if len(stack) == 0 {
+		return nil
+	}
+	frames := make([]sentry.Frame, 0, len(stack))
+	for i := len(stack) - 1; i >= 0; i-- {
+		f := stack[i]
+		frames = append(frames, sentry.Frame{
+			Function: function(f.Name),
+			Module:   f.Package,
+			Filename: f.RelativePath(),
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    isInApp(f, inApp),
+		})
+	}
+	return &sentry.Stacktrace{Frames: frames}
+}
+
+// function returns the function name of a frame without the package name, e.g. "(*T).Method" for
+// "pkg.(*T).Method".
+func function(name string) string {
+	_, fn, ok := strings.Cut(name, ".")
+	if !ok {
+		return name
+	}
+	return fn
+}
+
+// isInApp returns true if the package of the frame belongs to one of the in-app modules, or to an application
+// module if there are none.
+func isInApp(f eris.StackFrame, inApp []string) bool {
+	if len(inApp) == 0 {
+		return f.Module() != ""
+	}
+	for _, module := range inApp {
+		if f.Package == module || strings.HasPrefix(f.Package, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// fingerprint returns the grouping fingerprint of an error chain. It consists of the type of the external root
+// cause and the IDs or message templates of all eris errors, so it doesn't vary with message arguments.
+func fingerprint(upErr eris.UnpackedError) []string {
+	var fp []string
+	if upErr.ErrExternal != nil {
+		fp = append(fp, fmt.Sprintf("%T", upErr.ErrExternal))
+	}
+	root := upErr.ErrRoot
+	if root.Msg != "" || len(root.Stack) > 0 {
+		fp = append(fp, fingerprintPart(root.ID(), root.Template()))
+	}
+	for _, link := range upErr.ErrChain {
+		fp = append(fp, fingerprintPart(link.ID(), link.Template()))
+	}
+	return fp
+}
+
+// fingerprintPart returns the ID of the definition an error was created from, or its message template.
+func fingerprintPart(id, template string) string {
+	if id != "" {
+		return id
+	}
+	return template
+}
@@ -0,0 +1,152 @@
+package sentryeris_test
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/risingwavelabs/eris"
+	"github.com/risingwavelabs/eris/sentryeris"
+)
+
+// fakeTransport records events instead of sending them.
+type fakeTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (f *fakeTransport) Configure(sentry.ClientOptions)        {}
+func (f *fakeTransport) Flush(time.Duration) bool              { return true }
+func (f *fakeTransport) FlushWithContext(context.Context) bool { return true }
+func (f *fakeTransport) Close()                                {}
+
+func (f *fakeTransport) SendEvent(event *sentry.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+}
+
+func loadTable(name string) error {
+	return eris.Wrapf(io.ErrUnexpectedEOF, "failed to read table %v", name)
+}
+
+func TestNewEvent(t *testing.T) {
+	err := eris.WithProperty(eris.Wrap(loadTable("users"), "failed to load catalog"), "password", eris.Sensitive("secret"))
+	err = eris.WithCode(err, eris.CodeDataLoss)
+	event := sentryeris.NewEvent(err, sentryeris.Options{})
+
+	if event.Level != sentry.LevelFatal {
+		t.Errorf("expected level { %v } got { %v }", sentry.LevelFatal, event.Level)
+	}
+	tags := map[string]string{"code": "data loss", "severity": "critical", "domain": "sentryeris", "component": "sentryeris"}
+	if !reflect.DeepEqual(event.Tags, tags) {
+		t.Errorf("expected tags { %v } got { %v }", tags, event.Tags)
+	}
+	kvs := sentry.Context{"password": "[redacted]"}
+	if !reflect.DeepEqual(event.Contexts["kvs"], kvs) {
+		t.Errorf("expected KVs { %v } got { %v }", kvs, event.Contexts["kvs"])
+	}
+	fingerprint := []string{"*errors.errorString", "failed to read table %v", "failed to load catalog"}
+	if !reflect.DeepEqual(event.Fingerprint, fingerprint) {
+		t.Errorf("expected fingerprint { %v } got { %v }", fingerprint, event.Fingerprint)
+	}
+
+	types := []string{"*errors.errorString", "internal", "data loss"}
+	values := []string{"unexpected EOF", "failed to read table users", "failed to load catalog"}
+	if len(event.Exception) != len(types) {
+		t.Fatalf("expected { %v } exceptions got { %v }", len(types), len(event.Exception))
+	}
+	for i, exc := range event.Exception {
+		if exc.Type != types[i] || exc.Value != values[i] {
+			t.Errorf("expected exception { %v: %v } got { %v: %v }", types[i], values[i], exc.Type, exc.Value)
+		}
+	}
+	if event.Exception[0].Stacktrace != nil {
+		t.Errorf("expected no stack trace for the external error")
+	}
+
+	// the most recent call is last, i.e. the frame where the root error was created
+	frames := event.Exception[1].Stacktrace.Frames
+	last := frames[len(frames)-1]
+	if last.Function != "loadTable" || last.Module != "github.com/risingwavelabs/eris/sentryeris" || last.Filename != "sentryeris_test.go" || !last.InApp {
+		t.Errorf("unexpected root frame { %+v }", last)
+	}
+	if !strings.HasSuffix(last.AbsPath, "sentryeris_test.go") || last.Lineno == 0 {
+		t.Errorf("unexpected root frame location { %v:%v }", last.AbsPath, last.Lineno)
+	}
+	wrapFrames := event.Exception[2].Stacktrace.Frames
+	if len(wrapFrames) != 1 || wrapFrames[0].Function != "TestNewEvent" {
+		t.Errorf("unexpected wrap frames { %+v }", wrapFrames)
+	}
+}
+
+func TestNewEventOptions(t *testing.T) {
+	def := eris.Define("storage.table_missing", eris.CodeNotFound, "table %v is missing")
+	err := def.New("users")
+	tests := map[string]struct {
+		options sentryeris.Options
+		inApp   bool
+	}{
+		"main module": {
+			inApp: true,
+		},
+		"other module": {
+			options: sentryeris.Options{InAppModules: []string{"github.com/acme"}},
+		},
+		"package prefix": {
+			options: sentryeris.Options{InAppModules: []string{"github.com/risingwavelabs"}},
+			inApp:   true,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			event := sentryeris.NewEvent(err, tc.options)
+			frames := event.Exception[0].Stacktrace.Frames
+			if inApp := frames[len(frames)-1].InApp; inApp != tc.inApp {
+				t.Errorf("%v: expected in-app { %v } got { %v }", desc, tc.inApp, inApp)
+			}
+			if event.Exception[0].Type != "storage.table_missing" || event.Tags["error_id"] != "storage.table_missing" {
+				t.Errorf("%v: expected definition ID as type and tag got { %v } { %v }", desc, event.Exception[0].Type, event.Tags)
+			}
+			if !reflect.DeepEqual(event.Fingerprint, []string{"storage.table_missing"}) {
+				t.Errorf("%v: unexpected fingerprint { %v }", desc, event.Fingerprint)
+			}
+			if event.Level != sentry.LevelInfo {
+				t.Errorf("%v: expected level { %v } got { %v }", desc, sentry.LevelInfo, event.Level)
+			}
+		})
+	}
+
+	if sentryeris.NewEvent(nil, sentryeris.Options{}) != nil {
+		t.Errorf("expected no event for nil error")
+	}
+}
+
+func TestCaptureError(t *testing.T) {
+	transport := &fakeTransport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{Dsn: "https://key@sentry.example.com/1", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub := sentry.NewHub(client, sentry.NewScope())
+
+	if id := sentryeris.CaptureError(hub, nil, sentryeris.Options{}); id != nil {
+		t.Errorf("expected no event ID for nil error got { %v }", *id)
+	}
+	id := sentryeris.CaptureError(hub, eris.Wrapf(loadTable("orders"), "query %v failed", 42), sentryeris.Options{})
+	if id == nil {
+		t.Fatal("expected event ID")
+	}
+	if len(transport.events) != 1 {
+		t.Fatalf("expected { 1 } event got { %v }", len(transport.events))
+	}
+	event := transport.events[0]
+	if event.EventID != *id || len(event.Exception) != 3 || event.Tags["code"] != "internal" {
+		t.Errorf("unexpected event { %+v }", event)
+	}
+}
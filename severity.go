@@ -0,0 +1,65 @@
+package eris
+
+// Severity is the level of an error, used to decide e.g. the log level or whether to page someone.
+type Severity uint8
+
+// The severities in ascending order. The zero value means the severity is not set.
+const (
+	// SeverityDebug is for errors that are expected and only interesting while debugging.
+	SeverityDebug Severity = iota + 1
+	// SeverityInfo is for errors caused by the client, e.g. invalid arguments.
+	SeverityInfo
+	// SeverityWarning is for transient errors that usually resolve themselves, e.g. unavailable services.
+	SeverityWarning
+	// SeverityError is for errors that need to be looked at.
+	SeverityError
+	// SeverityCritical is for errors that need immediate attention, e.g. data loss.
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityDebug:    "debug",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "unset"
+}
+
+// Severities returns a Field of SeverityType.
+func Severities(severity Severity) Field {
+	return Field{
+		Type:  SeverityType,
+		Value: severity,
+	}
+}
+
+// WithSeverity sets the severity of an error, overriding the default derived from its code.
+func WithSeverity(err error, severity Severity) error {
+	return With(err, Severities(severity))
+}
+
+// GetSeverity returns the severity of the outermost error in the chain that sets one. If no error sets a severity,
+// it is derived from the effective code of the chain, see Code.Severity.
+func GetSeverity(err error) Severity {
+	type Severer interface {
+		Severity() Severity
+	}
+	var severity Severity
+	walk(err, func(err error) bool {
+		if severityErr, ok := err.(Severer); ok {
+			severity = severityErr.Severity()
+		}
+		return severity == 0
+	})
+	if severity != 0 {
+		return severity
+	}
+	return EffectiveCode(err, OutermostExplicit).Severity()
+}
@@ -0,0 +1,94 @@
+package eris_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/risingwavelabs/eris"
+)
+
+func TestGetSeverity(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		severity eris.Severity
+	}{
+		"not found": {
+			err:      eris.New("table not found").WithCode(eris.CodeNotFound),
+			severity: eris.SeverityInfo,
+		},
+		"data loss": {
+			err:      eris.Wrap(eris.New("checksum mismatch").WithCode(eris.CodeDataLoss), "read failed"),
+			severity: eris.SeverityCritical,
+		},
+		"unavailable": {
+			err:      eris.New("node down").WithCode(eris.CodeUnavailable),
+			severity: eris.SeverityWarning,
+		},
+		"canceled": {
+			err:      eris.WithCode(eris.Wrap(errors.New("external"), "wrap"), eris.CodeCanceled),
+			severity: eris.SeverityDebug,
+		},
+		"default": {
+			err:      eris.New("unknown failure"),
+			severity: eris.SeverityError,
+		},
+		"explicit": {
+			err:      eris.New("table not found").WithCode(eris.CodeNotFound).WithSeverity(eris.SeverityCritical),
+			severity: eris.SeverityCritical,
+		},
+		"outermost explicit wins": {
+			err:      eris.WithSeverity(eris.Wrap(eris.New("root").WithSeverity(eris.SeverityCritical), "wrap"), eris.SeverityDebug),
+			severity: eris.SeverityDebug,
+		},
+		"inner explicit": {
+			err:      eris.Wrap(eris.New("root").WithSeverity(eris.SeverityWarning), "wrap"),
+			severity: eris.SeverityWarning,
+		},
+		"field": {
+			err:      eris.With(errors.New("external"), eris.Severities(eris.SeverityInfo)),
+			severity: eris.SeverityInfo,
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if severity := eris.GetSeverity(tc.err); severity != tc.severity {
+				t.Errorf("%v: expected { %v } got { %v }", desc, tc.severity, severity)
+			}
+		})
+	}
+}
+
+func TestSeverityOutput(t *testing.T) {
+	err := eris.Wrap(eris.New("checksum mismatch").WithCode(eris.CodeDataLoss).WithSeverity(eris.SeverityCritical), "read failed")
+	expected := "code(internal) read failed: code(data loss) severity(critical) checksum mismatch"
+	if str := eris.ToString(err, false); str != expected {
+		t.Errorf("expected { %v } got { %v }", expected, str)
+	}
+
+	errJSON := eris.ToJSON(err, false)
+	if severity := errJSON["root"].(map[string]any)["severity"]; severity != "critical" {
+		t.Errorf("expected severity { %v } in root got { %v }", "critical", severity)
+	}
+	if _, ok := errJSON["wrap"].([]map[string]any)[0]["severity"]; ok {
+		t.Errorf("expected no severity in wrap without explicit severity")
+	}
+
+	// severities derived from codes are only rendered on request, once for the whole error
+	err = eris.Wrap(eris.New("checksum mismatch").WithCode(eris.CodeDataLoss), "read failed")
+	expected = "code(internal) read failed: code(data loss) checksum mismatch"
+	if str := eris.ToString(err, false); str != expected {
+		t.Errorf("expected { %v } got { %v }", expected, str)
+	}
+	options := eris.FormatOptions{WithExternal: true, WithSeverity: true}
+	expected = "severity(critical) code(internal) read failed: code(data loss) checksum mismatch"
+	if str := eris.ToCustomString(err, eris.NewDefaultStringFormat(options)); str != expected {
+		t.Errorf("expected { %v } got { %v }", expected, str)
+	}
+	errJSON = eris.ToCustomJSON(err, eris.NewDefaultJSONFormat(options))
+	if severity := errJSON["severity"]; severity != "critical" {
+		t.Errorf("expected severity { %v } got { %v }", "critical", severity)
+	}
+	if _, ok := errJSON["wrap"].([]map[string]any)[0]["severity"]; ok {
+		t.Errorf("expected no severity in wrap without explicit severity")
+	}
+}
@@ -0,0 +1,7 @@
+module github.com/risingwavelabs/eris/slogeris
+
+go 1.21
+
+require github.com/risingwavelabs/eris v0.1.0
+
+require google.golang.org/grpc v1.53.0 // indirect
@@ -0,0 +1,70 @@
+// Package slogeris logs eris errors with log/slog at the level derived from their severity.
+//
+//	slogeris.Log(ctx, logger, "compaction failed", err)
+//
+// The level is chosen from the error via eris.GetSeverity, so it isn't hard-coded at each call site. Sink adapts a
+// logger to an eris.Reporter.
+package slogeris
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/risingwavelabs/eris"
+)
+
+// LevelCritical is the slog level of critical errors, above slog.LevelError.
+const LevelCritical = slog.LevelError + 4
+
+// Level returns the slog level of a severity. Unset severities map to slog.LevelError.
+func Level(severity eris.Severity) slog.Level {
+	switch severity {
+	case eris.SeverityDebug:
+		return slog.LevelDebug
+	case eris.SeverityInfo:
+		return slog.LevelInfo
+	case eris.SeverityWarning:
+		return slog.LevelWarn
+	case eris.SeverityCritical:
+		return LevelCritical
+	default:
+		return slog.LevelError
+	}
+}
+
+// Log logs an error with the given logger at the level derived from the severity of the error, see
+// eris.GetSeverity. The error, its code and severity are added as attributes "error", "code" and "severity". Nil
+// errors are not logged.
+func Log(ctx context.Context, logger *slog.Logger, msg string, err error, attrs ...slog.Attr) {
+	if err == nil {
+		return
+	}
+	severity := eris.GetSeverity(err)
+	level := Level(severity)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	attrs = append([]slog.Attr{
+		slog.String("error", err.Error()),
+		slog.String("code", eris.EffectiveCode(err, eris.OutermostExplicit).String()),
+		slog.String("severity", severity.String()),
+	}, attrs...)
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// Sink returns a sink for eris.NewReporter that logs reports via Log with the message msg. Summaries are logged
+// with the attributes "count", "total", "first_seen" and "last_seen".
+func Sink(logger *slog.Logger, msg string) eris.Sink {
+	return func(r eris.Report) {
+		if !r.Summary {
+			Log(context.Background(), logger, msg, r.Err)
+			return
+		}
+		Log(context.Background(), logger, msg, r.Err,
+			slog.Int("count", r.Count),
+			slog.Int("total", r.Total),
+			slog.Time("first_seen", r.FirstSeen),
+			slog.Time("last_seen", r.LastSeen),
+		)
+	}
+}
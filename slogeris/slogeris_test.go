@@ -0,0 +1,78 @@
+package slogeris_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/risingwavelabs/eris"
+	"github.com/risingwavelabs/eris/slogeris"
+)
+
+func TestLog(t *testing.T) {
+	tests := map[string]struct {
+		err   error
+		level string
+	}{
+		"info": {
+			err:   eris.New("table not found").WithCode(eris.CodeNotFound),
+			level: "INFO",
+		},
+		"warning": {
+			err:   eris.New("node down").WithCode(eris.CodeUnavailable),
+			level: "WARN",
+		},
+		"critical": {
+			err:   eris.New("checksum mismatch").WithCode(eris.CodeDataLoss),
+			level: "ERROR+4",
+		},
+		"explicit": {
+			err:   eris.New("checksum mismatch").WithCode(eris.CodeDataLoss).WithSeverity(eris.SeverityError),
+			level: "ERROR",
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			slogeris.Log(context.Background(), logger, "request failed", tc.err, slog.String("table", "users"))
+
+			var record map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("%v: expected a JSON record got { %v }", desc, buf.String())
+			}
+			if record["level"] != tc.level {
+				t.Errorf("%v: expected level { %v } got { %v }", desc, tc.level, record["level"])
+			}
+			if record["code"] != eris.GetCode(tc.err).String() || record["error"] != tc.err.Error() || record["table"] != "users" {
+				t.Errorf("%v: expected error attributes got { %v }", desc, record)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	slogeris.Log(context.Background(), logger, "request failed", eris.New("table not found").WithCode(eris.CodeNotFound))
+	slogeris.Log(context.Background(), logger, "request failed", nil)
+	if buf.Len() != 0 {
+		t.Errorf("expected no record below the minimum level got { %v }", buf.String())
+	}
+}
+
+func TestSink(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := eris.NewReporter(slogeris.Sink(slog.New(slog.NewTextHandler(&buf, nil)), "scan failed"), eris.ReporterOptions{Interval: time.Hour})
+	for i := 0; i < 3; i++ {
+		reporter.Report(eris.New("failed to scan users").WithCode(eris.CodeUnavailable))
+	}
+	reporter.Close()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `level=WARN msg="scan failed"`) || strings.Contains(lines[0], "count=") ||
+		!strings.Contains(lines[1], "count=2 total=3 first_seen=") {
+		t.Errorf("unexpected output { %v }", buf.String())
+	}
+}
@@ -2,36 +2,86 @@ package eris
 
 import (
 	"fmt"
+	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 )
 
 // Stack is an array of stack frames stored in a human readable format.
 type Stack []StackFrame
 
 // format returns an array of formatted stack frames.
-func (s Stack) format(sep string, invert bool) []string {
+func (s Stack) format(sep string, options FormatOptions) []string {
 	var str []string
 	for _, f := range s {
-		if invert {
-			str = append(str, f.format(sep))
+		if options.InvertTrace {
+			str = append(str, f.format(sep, options))
 		} else {
-			str = append([]string{f.format(sep)}, str...)
+			str = append([]string{f.format(sep, options)}, str...)
 		}
 	}
 	return str
 }
 
-// StackFrame stores a frame's runtime information in a human readable format.
+// StackFrame stores a frame's runtime information in a human readable format. Package is the import path of
+// the directory containing the function, i.e. external test packages report the package they test.
 type StackFrame struct {
-	Name string
-	File string
-	Line int
+	Name    string
+	File    string
+	Line    int
+	Package string
 }
 
 // format returns a formatted stack frame.
-func (f *StackFrame) format(sep string) string {
-	return fmt.Sprintf("%v%v%v%v%v", f.Name, sep, f.File, sep, f.Line)
+func (f *StackFrame) format(sep string, options FormatOptions) string {
+	file := f.File
+	if options.RelativePaths {
+		file = f.RelativePath()
+	}
+	if options.MaskLines {
+		return fmt.Sprintf("%v%v%v%v*", f.Name, sep, file, sep)
+	}
+	return fmt.Sprintf("%v%v%v%v%v", f.Name, sep, file, sep, f.Line)
+}
+
+// RelativePath returns the path of the frame's file relative to its application module, e.g.
+// "storage/compactor.go", see Module. Files of dependencies and the standard library are prefixed with their
+// package path instead, e.g. "runtime/proc.go". Frames without package are returned unchanged.
+func (f *StackFrame) RelativePath() string {
+	if f.Package == "" {
+		return f.File
+	}
+	base := path.Base(filepath.ToSlash(f.File))
+	switch module := f.Module(); {
+	case module == "":
+		return f.Package + "/" + base
+	case f.Package == module:
+		return base
+	default:
+		return strings.TrimPrefix(f.Package, module+"/") + "/" + base
+	}
+}
+
+// deterministicTrace is the flag set by SetDeterministicTrace.
+var deterministicTrace atomic.Bool
+
+// SetDeterministicTrace controls whether ToString, ToJSON and the %+v verb render stack frames with paths
+// relative to their module and masked line numbers, see FormatOptions. This makes the output independent of
+// the machine, e.g. for examples with an output comment:
+//
+//	func Example() {
+//		eris.SetDeterministicTrace(true)
+//		defer eris.SetDeterministicTrace(false)
+//		fmt.Printf("%+v\n", eris.New("failed"))
+//		// Output:
+//		// code(unknown) failed
+//		//	eris_test.Example:example_test.go:*
+//		//	...
+//	}
+func SetDeterministicTrace(enabled bool) {
+	deterministicTrace.Store(enabled)
 }
 
 // caller returns a single stack frame. the argument skip is the number of stack frames
@@ -60,9 +110,10 @@ func (f frame) get() StackFrame {
 	name := frame.Function[i+1:]
 
 	return StackFrame{
-		Name: name,
-		File: frame.File,
-		Line: frame.Line,
+		Name:    name,
+		File:    frame.File,
+		Line:    frame.Line,
+		Package: packagePath(frame.Function),
 	}
 }
 
@@ -110,9 +161,10 @@ func (s *stack) get() []StackFrame {
 		i := strings.LastIndex(frame.Function, "/")
 		name := frame.Function[i+1:]
 		stackFrames = append(stackFrames, StackFrame{
-			Name: name,
-			File: frame.File,
-			Line: frame.Line,
+			Name:    name,
+			File:    frame.File,
+			Line:    frame.Line,
+			Package: packagePath(frame.Function),
 		})
 		if !more {
 			break
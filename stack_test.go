@@ -203,3 +203,52 @@ func TestGoRoutines(t *testing.T) {
 func dummyStack() error {
 	return eris.New("unexpected EOF").WithCode(eris.CodeUnknown)
 }
+
+func TestDeterministicTrace(t *testing.T) {
+	err := eris.Wrap(dummyStack(), "wrap")
+	tests := map[string]struct {
+		options  eris.FormatOptions
+		contains []string
+		excludes []string
+	}{
+		"relative paths": {
+			options:  eris.FormatOptions{WithTrace: true, RelativePaths: true},
+			contains: []string{"eris_test.dummyStack:stack_test.go:"},
+			excludes: []string{file, ":*"},
+		},
+		"masked lines": {
+			options:  eris.FormatOptions{WithTrace: true, MaskLines: true},
+			contains: []string{file + ":*"},
+		},
+		"both": {
+			options:  eris.FormatOptions{WithTrace: true, RelativePaths: true, MaskLines: true},
+			contains: []string{"eris_test.dummyStack:stack_test.go:*", "eris_test.TestDeterministicTrace:stack_test.go:*\n"},
+			excludes: []string{file},
+		},
+	}
+	for desc, tc := range tests {
+		t.Run(desc, func(t *testing.T) {
+			str := eris.ToCustomString(err, eris.NewDefaultStringFormat(tc.options))
+			for _, s := range tc.contains {
+				if !strings.Contains(str, s) {
+					t.Errorf("%v: expected { %v } to contain { %v }", desc, str, s)
+				}
+			}
+			for _, s := range tc.excludes {
+				if strings.Contains(str, s) {
+					t.Errorf("%v: expected { %v } not to contain { %v }", desc, str, s)
+				}
+			}
+		})
+	}
+
+	eris.SetDeterministicTrace(true)
+	defer eris.SetDeterministicTrace(false)
+	if str := fmt.Sprintf("%+v", err); !strings.Contains(str, "eris_test.dummyStack:stack_test.go:*") {
+		t.Errorf("expected deterministic trace got { %v }", str)
+	}
+	stack := eris.ToJSON(err, true)["root"].(map[string]any)["stack"].([]string)
+	if stack[len(stack)-1] != "eris_test.dummyStack:stack_test.go:*" {
+		t.Errorf("expected deterministic JSON trace got { %v }", stack)
+	}
+}